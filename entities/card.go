@@ -104,6 +104,11 @@ func (h *Hand) TotalValue() int {
 
 type ShuffleOpts struct {
 	NumDecks int
+	// Penetration is the fraction of the shoe dealt before the cut card comes out
+	Penetration float64
+	// ReshuffleDiscards shuffles the discard pile back in if the draw pile runs
+	// out in the middle of a hand, instead of failing the draw
+	ReshuffleDiscards bool
 }
 
 type DrawOpts struct {
@@ -0,0 +1,69 @@
+package evaluator
+
+import (
+	"slices"
+
+	"casino/entities"
+)
+
+// Category is a five-card poker hand, from worst to best
+type Category int
+
+const (
+	HighCard Category = iota
+	Pair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+	// RoyalFlush is an ace high straight flush. It's only its own category so
+	// paytables can pay it separately.
+	RoyalFlush
+)
+
+var categoryNames = map[Category]string{
+	HighCard:      "High card",
+	Pair:          "Pair",
+	TwoPair:       "Two pair",
+	ThreeOfAKind:  "Three of a kind",
+	Straight:      "Straight",
+	Flush:         "Flush",
+	FullHouse:     "Full house",
+	FourOfAKind:   "Four of a kind",
+	StraightFlush: "Straight flush",
+	RoyalFlush:    "Royal flush",
+}
+
+func (c Category) String() string {
+	return categoryNames[c]
+}
+
+// BestFive finds the best five-card hand that can be made from 5 to 7 cards.
+// Returns its rank and the five cards that make it. Use Evaluate when only the
+// rank is needed, it's much faster.
+func BestFive(cards []entities.Card) (Rank, []entities.Card) {
+	var best Rank
+	var bestCards []entities.Card
+
+	hand := make([]entities.Card, 5)
+	var choose func(start, picked int)
+	choose = func(start, picked int) {
+		if picked == len(hand) {
+			if rank := Evaluate(hand); bestCards == nil || rank > best {
+				best = rank
+				bestCards = slices.Clone(hand)
+			}
+			return
+		}
+		for i := start; i <= len(cards)-(len(hand)-picked); i++ {
+			hand[picked] = cards[i]
+			choose(i+1, picked+1)
+		}
+	}
+	choose(0, 0)
+
+	return best, bestCards
+}
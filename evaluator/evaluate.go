@@ -0,0 +1,136 @@
+package evaluator
+
+import (
+	"math/bits"
+
+	"casino/entities"
+)
+
+// wheelMask is 5-4-3-2-A, with the ace playing low
+const wheelMask = 1<<AceHigh | 0b11110
+
+// Evaluate ranks the best five-card poker hand that can be made from 5 to 7
+// cards. The cards are left as they are, and nothing is allocated, so it's
+// cheap enough to call millions of times in a simulation.
+func Evaluate(cards []entities.Card) Rank {
+	// Bit r of a mask is set if a card of rank r is held
+	var suitMasks [4]uint16
+	var counts [AceHigh + 1]int
+	var all uint16
+	for _, card := range cards {
+		rank := CardRank(card)
+		suitMasks[suitIndex(card.Suit)] |= 1 << rank
+		counts[rank]++
+		all |= 1 << rank
+	}
+
+	for _, mask := range suitMasks {
+		if bits.OnesCount16(mask) < 5 {
+			continue
+		}
+
+		switch high := straightHigh(mask); {
+		case high == AceHigh:
+			return newRank(int(RoyalFlush), high)
+		case high > 0:
+			return newRank(int(StraightFlush), high)
+		}
+
+		var kickers [5]int
+		return newRank(int(Flush), topRanks(mask, kickers[:])...)
+	}
+
+	// The best rank held four, three and two times, and the second best held
+	// three or two times for full houses and two pair
+	var quads, trips, secondTrips, pair, secondPair int
+	for rank := AceHigh; rank > 0; rank-- {
+		switch counts[rank] {
+		case 4:
+			quads = rank
+		case 3:
+			if trips == 0 {
+				trips = rank
+			} else if secondTrips == 0 {
+				secondTrips = rank
+			}
+		case 2:
+			if pair == 0 {
+				pair = rank
+			} else if secondPair == 0 {
+				secondPair = rank
+			}
+		}
+	}
+
+	var kickers [5]int
+	switch {
+	case quads > 0:
+		kickers[0] = quads
+		topRanks(all&^(1<<quads), kickers[1:2])
+		return newRank(int(FourOfAKind), kickers[:2]...)
+	case trips > 0 && (secondTrips > 0 || pair > 0):
+		return newRank(int(FullHouse), trips, max(secondTrips, pair))
+	}
+
+	if high := straightHigh(all); high > 0 {
+		return newRank(int(Straight), high)
+	}
+
+	switch {
+	case trips > 0:
+		kickers[0] = trips
+		topRanks(all&^(1<<trips), kickers[1:3])
+		return newRank(int(ThreeOfAKind), kickers[:3]...)
+	case secondPair > 0:
+		kickers[0], kickers[1] = pair, secondPair
+		topRanks(all&^(1<<pair|1<<secondPair), kickers[2:3])
+		return newRank(int(TwoPair), kickers[:3]...)
+	case pair > 0:
+		kickers[0] = pair
+		topRanks(all&^(1<<pair), kickers[1:4])
+		return newRank(int(Pair), kickers[:4]...)
+	default:
+		return newRank(int(HighCard), topRanks(all, kickers[:])...)
+	}
+}
+
+// straightHigh returns the rank of the top card of the best straight in a rank
+// mask, or 0 if there isn't one
+func straightHigh(mask uint16) int {
+	for high := AceHigh; high >= 5; high-- {
+		run := uint16(0b11111) << (high - 4)
+		if mask&run == run {
+			return high
+		}
+	}
+	if mask&wheelMask == wheelMask {
+		return 4
+	}
+
+	return 0
+}
+
+// topRanks fills kickers with the highest ranks in a rank mask, best first, and
+// returns them
+func topRanks(mask uint16, kickers []int) []int {
+	for i := range kickers {
+		rank := bits.Len16(mask) - 1
+		kickers[i] = rank
+		mask &^= 1 << rank
+	}
+
+	return kickers
+}
+
+func suitIndex(suit entities.StandardSuit) int {
+	switch suit {
+	case entities.Spade:
+		return 0
+	case entities.Club:
+		return 1
+	case entities.Heart:
+		return 2
+	default:
+		return 3
+	}
+}
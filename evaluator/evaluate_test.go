@@ -0,0 +1,148 @@
+package evaluator
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"casino/entities"
+)
+
+// rankCodes are the rank letters used in test hands, in SortValue order
+var rankCodes = []entities.StandardRank{
+	entities.Ace, entities.Two, entities.Three, entities.Four, entities.Five, entities.Six, entities.Seven,
+	entities.Eight, entities.Nine, entities.Ten, entities.Jack, entities.Queen, entities.King,
+}
+
+var suitCodes = map[byte]entities.StandardSuit{
+	's': entities.Spade,
+	'c': entities.Club,
+	'h': entities.Heart,
+	'd': entities.Diamond,
+}
+
+// cards parses a hand written like "As Td 7h", with T for a ten
+func cards(t testing.TB, hand string) []entities.Card {
+	t.Helper()
+
+	var parsed []entities.Card
+	for _, code := range strings.Fields(hand) {
+		rank := entities.StandardRank(code[:1])
+		if rank == "T" {
+			rank = entities.Ten
+		}
+		sortValue := slices.Index(rankCodes, rank)
+		suit, ok := suitCodes[code[1]]
+		if sortValue < 0 || !ok || len(code) != 2 {
+			t.Fatalf("bad card %q", code)
+		}
+		parsed = append(parsed, entities.Card{Code: code, Rank: rank, Suit: suit, SortValue: sortValue})
+	}
+
+	return parsed
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     string
+		category Category
+		kickers  []int
+	}{
+		{"wheel", "As 2d 3c 4h 5s", Straight, []int{4}},
+		{"six high straight", "2d 3c 4h 5s 6d", Straight, []int{5}},
+		{"ace high straight", "Ts Jd Qc Kh As 2c", Straight, []int{AceHigh}},
+		{"7 card flush takes the top 5 suited cards", "Ah Kh 9h 7h 4h 2h Qs", Flush, []int{AceHigh, 12, 8, 6, 3}},
+		{"two trips make a full house", "Ks Kh Kd 7s 7h 7d 2c", FullHouse, []int{12, 6}},
+		{"trips and two pair make the best full house", "4s 4h 4d Js Jh 9d 9c", FullHouse, []int{3, 10}},
+		{"straight flush hidden in a flush", "9h 8h 7h 6h 5h Ah 2h", StraightFlush, []int{8}},
+		{"steel wheel", "Ah 2h 3h 4h 5h Kh Kd", StraightFlush, []int{4}},
+		{"royal flush", "Ah Kh Qh Jh Th 2c 3d", RoyalFlush, []int{AceHigh}},
+		{"quads take the best kicker", "9s 9h 9d 9c Ks Kd 3c", FourOfAKind, []int{8, 12}},
+		{"three pairs play the top two", "Ks Kh 8d 8c 4s 4h Ad", TwoPair, []int{12, 7, AceHigh}},
+		{"pair with three kickers", "Js Jh 9d 7c 4s 3h 2d", Pair, []int{10, 8, 6, 3}},
+		{"high card uses five cards", "As Jh 9d 7c 4s 3h 2d", HighCard, []int{AceHigh, 10, 8, 6, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rank := Evaluate(cards(t, test.hand))
+			if Category(rank.Category()) != test.category || !slices.Equal(rank.Kickers(), test.kickers) {
+				t.Errorf("Evaluate(%s) = %s %v, want %s %v",
+					test.hand, Category(rank.Category()), rank.Kickers(), test.category, test.kickers)
+			}
+		})
+	}
+}
+
+func TestEvaluateTies(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       string
+		second      string
+		firstIsBest int // 1 if first wins, -1 if second wins, 0 for a split
+	}{
+		{"wheel is below a six high straight", "As 2d 3c 4h 5s", "2d 3c 4h 5s 6d", -1},
+		{"board plays for both", "Ks Kh 8d 8c Qs 2c 3d", "Ks Kh 8d 8c Qs 4c 5d", 0},
+		{"kicker decides", "As Ad 9c 7h 4s Kd 2c", "As Ad 9c 7h 4s Qd 3c", 1},
+		{"sixth card doesn't play", "As Ad Kc Qh 9s 8d 2c", "As Ad Kc Qh 9s 7d 3c", 0},
+		{"fifth flush card decides", "Ah Kh 9h 7h 4h", "Ah Kh 9h 7h 3h", 1},
+		{"two pair kicker decides", "Ks Kh 8d 8c Qs 2c 3d", "Ks Kh 8d 8c Js Ac 3d", -1},
+		{"counterfeited two pair splits", "9s 9h 5d 5c Qs Qd 2c", "9s 9h 5d 5c Qs Qc 3c", 0},
+		{"full house ranks on trips first", "Qs Qh Qd 2s 2h", "Js Jh Jd As Ah", 1},
+		{"same straight splits regardless of suits", "5s 6h 7d 8c 9s Kd", "5h 6d 7c 8s 9d Qh", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := Evaluate(cards(t, test.first)), Evaluate(cards(t, test.second))
+			got := 0
+			switch {
+			case first > second:
+				got = 1
+			case first < second:
+				got = -1
+			}
+			if got != test.firstIsBest {
+				t.Errorf("%s vs %s = %d, want %d", test.first, test.second, got, test.firstIsBest)
+			}
+		})
+	}
+}
+
+func TestBestFive(t *testing.T) {
+	rank, best := BestFive(cards(t, "Ah Kh 9h 7h 4h 2h Qs"))
+	if rank != Evaluate(best) {
+		t.Errorf("BestFive rank %d doesn't match its cards' rank %d", rank, Evaluate(best))
+	}
+
+	var codes []string
+	for _, card := range best {
+		codes = append(codes, card.Code)
+	}
+	if want := []string{"Ah", "Kh", "9h", "7h", "4h"}; !slices.Equal(codes, want) {
+		t.Errorf("BestFive picked %v, want %v", codes, want)
+	}
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	var deck []string
+	for _, rank := range "A23456789TJQK" {
+		for _, suit := range "schd" {
+			deck = append(deck, string(rank)+string(suit))
+		}
+	}
+
+	hands := make([][]entities.Card, 1024)
+	for i := range hands {
+		rand.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hands[i] = cards(b, strings.Join(deck[:7], " "))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Evaluate(hands[i%len(hands)])
+	}
+}
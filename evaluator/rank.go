@@ -0,0 +1,60 @@
+package evaluator
+
+import "casino/entities"
+
+// AceHigh is the rank of an ace when it plays above a king
+const AceHigh = 13
+
+// CardRank ranks a card for poker with the ace above the king: 2 is 1 up to a
+// king at 12 and an ace at 13
+func CardRank(card entities.Card) int {
+	if card.Rank == entities.Ace {
+		return AceHigh
+	}
+
+	return card.SortValue
+}
+
+const (
+	maxKickers = 5
+	kickerBits = 4
+)
+
+// Rank is the strength of a poker hand: its category, then the card ranks that
+// break a tie between two hands of that category, most important first. A
+// better hand always has a higher Rank, so ranks can be compared directly with
+// < and ==.
+type Rank uint32
+
+func newRank(category int, kickers ...int) Rank {
+	rank := Rank(category)
+	for i := range maxKickers {
+		rank <<= kickerBits
+		if i < len(kickers) {
+			rank |= Rank(kickers[i])
+		}
+	}
+
+	return rank
+}
+
+// Category is the kind of hand, e.g. a pair. What each value means depends on
+// the game the hand was evaluated for.
+func (r Rank) Category() int {
+	return int(r >> (maxKickers * kickerBits))
+}
+
+// Kickers are the card ranks, as given by CardRank, that break a tie between two
+// hands of the same category, most important first
+func (r Rank) Kickers() []int {
+	var kickers []int
+	for i := maxKickers - 1; i >= 0; i-- {
+		kicker := int(r>>(i*kickerBits)) & (1<<kickerBits - 1)
+		if kicker == 0 {
+			break
+		}
+		kickers = append(kickers, kicker)
+	}
+
+	return kickers
+}
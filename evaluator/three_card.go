@@ -0,0 +1,68 @@
+package evaluator
+
+import (
+	"slices"
+
+	"casino/entities"
+)
+
+// ThreeCardCategory is a 3-card poker hand, from worst to best. With only three
+// cards a straight is harder to make than a flush, so it ranks higher.
+type ThreeCardCategory int
+
+const (
+	ThreeCardHighCard ThreeCardCategory = iota
+	ThreeCardPair
+	ThreeCardFlush
+	ThreeCardStraight
+	ThreeCardThreeOfAKind
+	ThreeCardStraightFlush
+)
+
+// ThreeCard ranks a 3-card poker hand. The cards are left as they are.
+func ThreeCard(cards []entities.Card) Rank {
+	ranks := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ranks = append(ranks, CardRank(card))
+	}
+	slices.Sort(ranks)
+	slices.Reverse(ranks)
+
+	isFlush := cards[0].Suit == cards[1].Suit && cards[1].Suit == cards[2].Suit
+	straightHigh := threeCardStraightHigh(ranks)
+
+	switch {
+	case isFlush && straightHigh > 0:
+		return newRank(int(ThreeCardStraightFlush), straightHigh)
+	case ranks[0] == ranks[2]:
+		return newRank(int(ThreeCardThreeOfAKind), ranks[0])
+	case straightHigh > 0:
+		return newRank(int(ThreeCardStraight), straightHigh)
+	case isFlush:
+		return newRank(int(ThreeCardFlush), ranks...)
+	case ranks[0] == ranks[1]:
+		return newRank(int(ThreeCardPair), ranks[0], ranks[2])
+	case ranks[1] == ranks[2]:
+		return newRank(int(ThreeCardPair), ranks[1], ranks[0])
+	default:
+		return newRank(int(ThreeCardHighCard), ranks...)
+	}
+}
+
+// threeCardStraightHigh returns the rank of the top card of a straight, or 0 if
+// the ranks (sorted high to low) aren't a straight. The ace plays high in A-K-Q
+// and low in 3-2-A, which is the lowest straight. Straights don't wrap around,
+// so 2-A-K is not one.
+func threeCardStraightHigh(ranks []int) int {
+	top, middle, bottom := ranks[0], ranks[1], ranks[2]
+	if top == middle+1 && middle == bottom+1 {
+		return top
+	}
+
+	// 3-2-A: the ace sorts first but plays low
+	if top == AceHigh && middle == 2 && bottom == 1 {
+		return middle
+	}
+
+	return 0
+}
@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestThreeCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     string
+		category ThreeCardCategory
+		kickers  []int
+	}{
+		{"A-2-3 is a straight with the ace low", "As 2d 3c", ThreeCardStraight, []int{2}},
+		{"Q-K-A is a straight with the ace high", "Qs Kd Ac", ThreeCardStraight, []int{AceHigh}},
+		{"K-A-2 doesn't wrap around", "Ks Ad 2c", ThreeCardHighCard, []int{AceHigh, 12, 1}},
+		{"suited A-2-3 is a straight flush", "Ah 2h 3h", ThreeCardStraightFlush, []int{2}},
+		{"three of a kind", "7s 7d 7c", ThreeCardThreeOfAKind, []int{6}},
+		{"flush keeps every card", "2h 7h Jh", ThreeCardFlush, []int{10, 6, 1}},
+		{"pair with an ace kicker", "9s 9d Ac", ThreeCardPair, []int{8, AceHigh}},
+		{"pair with a low kicker", "2s Kd Kc", ThreeCardPair, []int{12, 1}},
+		{"ace high", "4c As 7d", ThreeCardHighCard, []int{AceHigh, 6, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rank := ThreeCard(cards(t, test.hand))
+			if ThreeCardCategory(rank.Category()) != test.category || !slices.Equal(rank.Kickers(), test.kickers) {
+				t.Errorf("ThreeCard(%s) = %d %v, want %d %v",
+					test.hand, rank.Category(), rank.Kickers(), test.category, test.kickers)
+			}
+		})
+	}
+}
+
+func TestThreeCardTies(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       string
+		second      string
+		firstIsBest int // 1 if first wins, -1 if second wins, 0 for a split
+	}{
+		{"A-2-3 is below 2-3-4", "As 2d 3c", "2s 3d 4c", -1},
+		{"Q-K-A is above J-Q-K", "Qs Kd Ac", "Js Qd Kh", 1},
+		{"flush is below a straight", "2h 7h Jh", "4s 5d 6c", -1},
+		{"straight is below three of a kind", "Qs Kd Ac", "2s 2d 2c", -1},
+		{"ace high beats king high", "As 3d 2c", "Ks Qd 9h", 1},
+		{"second card decides", "As Jd 4c", "Ah 9s 8c", 1},
+		{"third card decides", "Kd 9s 4c", "Ks 9h 5d", -1},
+		{"pair kicker decides", "9s 9d Ac", "9h 9c Kd", 1},
+		{"flush third card decides", "Kh 9h 5h", "Ks 9s 4s", 1},
+		{"same ranks split", "Kd 9s 5c", "Kh 9d 5s", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := ThreeCard(cards(t, test.first)), ThreeCard(cards(t, test.second))
+			got := 0
+			switch {
+			case first > second:
+				got = 1
+			case first < second:
+				got = -1
+			}
+			if got != test.firstIsBest {
+				t.Errorf("%s vs %s = %d, want %d", test.first, test.second, got, test.firstIsBest)
+			}
+		})
+	}
+}
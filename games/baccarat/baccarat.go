@@ -0,0 +1,418 @@
+package baccarat
+
+import (
+	"context"
+	"fmt"
+
+	"casino/entities"
+	"casino/games"
+	"casino/utils"
+)
+
+type baccarat struct {
+	dealer        utils.Dealer
+	saveManager   utils.SaveDataManager
+	variant       Variant
+	variantChosen bool
+
+	playerHand entities.Hand
+	bankerHand entities.Hand
+	side       Side
+	wager      int
+	dragon     int
+	panda      int
+	userChips  int
+	history    []Outcome // every coup since the shoe was shuffled
+
+	in  chan string
+	out chan string
+}
+
+func NewBaccarat(
+	dealer utils.Dealer,
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	b := &baccarat{
+		dealer:      dealer,
+		saveManager: saveManager,
+		in:          in,
+		out:         out,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Option configures a baccarat game
+type Option func(*baccarat)
+
+// WithVariant sets which version of baccarat is dealt. The player won't be asked
+// to pick one.
+func WithVariant(variant Variant) Option {
+	return func(b *baccarat) {
+		b.variant = variant
+		b.variantChosen = true
+	}
+}
+
+func (b *baccarat) Name() string {
+	return "Baccarat"
+}
+
+// Play runs coups until the player chooses to leave the table. Every coup
+// returns here before the next one starts.
+func (b *baccarat) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := b.saveManager.Read().RemainingChips
+
+	for {
+		played, err := b.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = b.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = b.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the player's bets, deals one coup and settles it. Returns
+// whether a coup was dealt. Nothing is asked of the player once the bets are
+// down, so a coup that's dealt is always settled.
+func (b *baccarat) playRound(ctx context.Context) (bool, error) {
+	utils.Clear(b.out)
+	save := b.saveManager.Read()
+	b.userChips = save.RemainingChips
+
+	utils.PrintBanner(b.Name(), b.out)
+	if !b.variantChosen {
+		if err := b.chooseVariant(ctx); err != nil {
+			return false, err
+		}
+	}
+	b.out <- utils.Dim("%s", VariantToString[b.variant])
+
+	if b.dealer.NeedsShuffle() {
+		b.out <- utils.Yellow("Cut card is out, shuffling %d decks...", b.dealer.NumDecks())
+		b.dealer.Shuffle()
+		b.history = nil
+	}
+	b.printRoads()
+
+	if err := b.placeBets(ctx); err != nil {
+		return false, err
+	}
+
+	outcome, err := b.deal()
+	if err != nil {
+		b.misdeal(err)
+		return true, nil
+	}
+
+	b.printUpdate()
+	b.settle(outcome)
+	b.history = append(b.history, outcome)
+	b.endGame()
+
+	return true, nil
+}
+
+// chooseVariant asks the player which version of baccarat they want to play
+func (b *baccarat) chooseVariant(ctx context.Context) error {
+	b.out <- "Pick a table:"
+	for _, variant := range []Variant{Commission, EZ} {
+		b.out <- fmt.Sprintf("%d. %s", variant+1, VariantToString[variant])
+	}
+
+	choice, err := utils.GetInput(ctx, b.in, b.out, []string{"1", "2"}, "Which table? (1, 2)")
+	if err != nil {
+		return err
+	}
+	b.variant = Commission
+	if choice == "2" {
+		b.variant = EZ
+	}
+	b.variantChosen = true
+
+	return nil
+}
+
+// placeBets takes the main bet and, in EZ baccarat, the side bets. Nothing is
+// taken from the player's chips unless all the bets are placed.
+func (b *baccarat) placeBets(ctx context.Context) error {
+	b.out <- utils.Dim("You have %d chips", b.userChips)
+	side, err := utils.GetInput(
+		ctx,
+		b.in,
+		b.out,
+		[]string{"player", "p", "banker", "b", "tie", "t"},
+		fmt.Sprintf("Bet on player (p), banker (b) or tie (t, pays %d to 1)?", TiePays),
+	)
+	if err != nil {
+		return err
+	}
+	switch side {
+	case "player", "p":
+		b.side = Player
+	case "banker", "b":
+		b.side = Banker
+	default:
+		b.side = Tie
+	}
+
+	wager, err := utils.GetBet(ctx, b.in, b.out, fmt.Sprintf("How much on %s? (max %d)", SideToString[b.side], b.userChips), 1, b.userChips)
+	if err != nil {
+		return err
+	}
+	remaining := b.userChips - wager
+
+	dragon, panda := 0, 0
+	if b.variant == EZ && remaining > 0 {
+		dragon, err = utils.GetBet(
+			ctx,
+			b.in,
+			b.out,
+			fmt.Sprintf("Dragon 7, banker wins with three cards totalling 7, pays %d to 1? (max %d)", DragonPays, remaining),
+			0,
+			remaining,
+		)
+		if err != nil {
+			return err
+		}
+		remaining -= dragon
+	}
+	if b.variant == EZ && remaining > 0 {
+		panda, err = utils.GetBet(
+			ctx,
+			b.in,
+			b.out,
+			fmt.Sprintf("Panda 8, player wins with three cards totalling 8, pays %d to 1? (max %d)", PandaPays, remaining),
+			0,
+			remaining,
+		)
+		if err != nil {
+			return err
+		}
+		remaining -= panda
+	}
+
+	b.wager, b.dragon, b.panda = wager, dragon, panda
+	save := b.saveManager.Read()
+	save.RemainingChips -= wager + dragon + panda
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+
+	return nil
+}
+
+// deal plays out the coup by the tableau: two cards each, then a third card for
+// either hand as the drawing rules say
+func (b *baccarat) deal() (Outcome, error) {
+	b.playerHand = entities.Hand{}
+	b.bankerHand = entities.Hand{}
+
+	// Player and banker are dealt alternately, player first
+	for range 2 {
+		if err := b.draw(&b.playerHand); err != nil {
+			return Outcome{}, err
+		}
+		if err := b.draw(&b.bankerHand); err != nil {
+			return Outcome{}, err
+		}
+	}
+
+	if !IsNatural(b.playerHand.Cards) && !IsNatural(b.bankerHand.Cards) {
+		playerDrew := PlayerDraws(HandTotal(b.playerHand.Cards))
+		playerThird := 0
+		if playerDrew {
+			if err := b.draw(&b.playerHand); err != nil {
+				return Outcome{}, err
+			}
+			playerThird = CardValue(b.playerHand.Cards[2])
+		}
+
+		if BankerDraws(HandTotal(b.bankerHand.Cards), playerDrew, playerThird) {
+			if err := b.draw(&b.bankerHand); err != nil {
+				return Outcome{}, err
+			}
+		}
+	}
+
+	outcome := Outcome{
+		PlayerTotal: HandTotal(b.playerHand.Cards),
+		BankerTotal: HandTotal(b.bankerHand.Cards),
+		PlayerCards: len(b.playerHand.Cards),
+		BankerCards: len(b.bankerHand.Cards),
+	}
+	switch {
+	case outcome.PlayerTotal > outcome.BankerTotal:
+		outcome.Winner = Player
+	case outcome.BankerTotal > outcome.PlayerTotal:
+		outcome.Winner = Banker
+	default:
+		outcome.Winner = Tie
+	}
+
+	return outcome, nil
+}
+
+func (b *baccarat) draw(hand *entities.Hand) error {
+	card, err := b.dealer.Draw()
+	if err != nil {
+		return err
+	}
+	hand.Cards = append(hand.Cards, card)
+
+	return nil
+}
+
+// settle announces the coup and pays the bets
+func (b *baccarat) settle(outcome Outcome) {
+	if outcome.Winner == Tie {
+		b.out <- utils.Bold("Tie, %d to %d", outcome.PlayerTotal, outcome.BankerTotal)
+	} else {
+		b.out <- utils.Bold("%s wins, %d to %d", SideToString[outcome.Winner], outcome.PlayerTotal, outcome.BankerTotal)
+	}
+
+	returned := 0
+	switch {
+	case b.side == outcome.Winner && b.side == Tie:
+		returned = b.wager + b.wager*TiePays
+		b.out <- utils.Green(utils.Bold("Your tie bet pays %d to 1! (+%d chips)", TiePays, b.wager*TiePays))
+	case b.side == outcome.Winner && b.side == Banker && b.variant == EZ && outcome.Dragon():
+		returned = b.wager
+		b.out <- utils.Yellow("Banker wins with a three card 7, your bet pushes")
+	case b.side == outcome.Winner && b.side == Banker && b.variant == Commission:
+		winnings := b.wager * (100 - CommissionPercent) / 100
+		returned = b.wager + winnings
+		b.out <- utils.Green("You win! (+%d chips after %d%% commission)", winnings, CommissionPercent)
+	case b.side == outcome.Winner:
+		returned = b.wager * 2
+		b.out <- utils.Green("You win! (+%d chips)", b.wager)
+	case outcome.Winner == Tie:
+		returned = b.wager
+		b.out <- utils.Yellow("Tie, your bet on %s pushes", SideToString[b.side])
+	default:
+		b.out <- utils.Red("You lose (-%d chips)", b.wager)
+	}
+
+	if b.dragon > 0 {
+		if outcome.Dragon() {
+			returned += b.dragon + b.dragon*DragonPays
+			b.out <- utils.Green(utils.Bold("Dragon 7! (+%d chips)", b.dragon*DragonPays))
+		} else {
+			b.out <- utils.Red("You lose your Dragon 7 bet (-%d chips)", b.dragon)
+		}
+	}
+	if b.panda > 0 {
+		if outcome.Panda() {
+			returned += b.panda + b.panda*PandaPays
+			b.out <- utils.Green(utils.Bold("Panda 8! (+%d chips)", b.panda*PandaPays))
+		} else {
+			b.out <- utils.Red("You lose your Panda 8 bet (-%d chips)", b.panda)
+		}
+	}
+
+	save := b.saveManager.Read()
+	save.RemainingChips += returned
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+}
+
+// misdeal calls off the coup when the dealer can't deal, giving back all the
+// bets
+func (b *baccarat) misdeal(err error) {
+	refund := b.wager + b.dragon + b.panda
+	save := b.saveManager.Read()
+	save.RemainingChips += refund
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+
+	b.out <- utils.Red("Misdeal: %s", err.Error())
+	b.out <- utils.Yellow("All wagers returned (+%d chips)", refund)
+
+	// The next coup checks the cut card, which an empty shoe is well past, so it
+	// starts from a fresh shuffle
+	b.endGame()
+}
+
+// endGame clears the table once the coup is settled
+func (b *baccarat) endGame() {
+	b.dealer.Discard(b.playerHand.Cards...)
+	b.dealer.Discard(b.bankerHand.Cards...)
+	b.playerHand = entities.Hand{}
+	b.bankerHand = entities.Hand{}
+}
+
+// playAgain asks whether the player wants another coup. Returns false if they
+// want to leave the table.
+func (b *baccarat) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		b.in,
+		b.out,
+		[]string{"yes", "y", "no", "n"},
+		"Play again? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// printRoads shows the bead plate and big road for the shoe so far
+func (b *baccarat) printRoads() {
+	if len(b.history) == 0 {
+		return
+	}
+
+	beadPlate := append([]string{"Bead plate"}, BeadPlate(b.history)...)
+	bigRoad := append([]string{"Big road"}, BigRoad(b.history)...)
+	for _, line := range utils.JoinColumns("   ", beadPlate, bigRoad) {
+		b.out <- line
+	}
+	b.out <- utils.Divider()
+}
+
+func (b *baccarat) printUpdate() {
+	utils.Clear(b.out)
+
+	b.out <- utils.Dim("Your chips: %d", b.userChips)
+	b.out <- utils.Dim("Bet on %s: %d", SideToString[b.side], b.wager)
+	if b.variant == EZ {
+		b.out <- utils.Dim("Dragon 7: %d, Panda 8: %d", b.dragon, b.panda)
+	}
+	b.out <- utils.Dim("Shoe: %d cards to the cut card", b.dealer.Remaining())
+	b.out <- utils.Divider()
+
+	player := append(
+		[]string{utils.Blue("Player: %d", HandTotal(b.playerHand.Cards))},
+		utils.RenderHand(b.playerHand)...,
+	)
+	banker := append(
+		[]string{utils.Red("Banker: %d", HandTotal(b.bankerHand.Cards))},
+		utils.RenderHand(b.bankerHand)...,
+	)
+	for _, line := range utils.JoinColumns("   ", player, banker) {
+		b.out <- line
+	}
+
+	b.out <- utils.Divider()
+}
@@ -0,0 +1,67 @@
+package baccarat
+
+const (
+	// Decks is how many decks are shuffled into the shoe
+	Decks = 8
+	// Penetration is how much of the shoe is dealt before it's reshuffled
+	Penetration = 0.85
+
+	// TiePays is what a tie bet pays, X to 1
+	TiePays = 8
+	// CommissionPercent is taken from banker wins in the commission game
+	CommissionPercent = 5
+	// DragonPays is what the Dragon 7 side bet pays, X to 1
+	DragonPays = 40
+	// PandaPays is what the Panda 8 side bet pays, X to 1
+	PandaPays = 25
+)
+
+// Variant is which version of baccarat is dealt
+type Variant int
+
+const (
+	// Commission pays banker bets 1 to 1 less a commission
+	Commission Variant = iota
+	// EZ pays banker bets 1 to 1 with no commission, except a banker win with a
+	// three card 7 pushes. It offers the Dragon 7 and Panda 8 side bets.
+	EZ
+)
+
+var VariantToString = map[Variant]string{
+	Commission: "Punto Banco (5% commission on banker wins)",
+	EZ:         "EZ Baccarat (no commission, Dragon 7 and Panda 8)",
+}
+
+// Side is one of the two hands, or a tie between them
+type Side int
+
+const (
+	Player Side = iota
+	Banker
+	Tie
+)
+
+var SideToString = map[Side]string{
+	Player: "Player",
+	Banker: "Banker",
+	Tie:    "Tie",
+}
+
+// Outcome is how a coup turned out
+type Outcome struct {
+	Winner      Side
+	PlayerTotal int
+	BankerTotal int
+	PlayerCards int
+	BankerCards int
+}
+
+// Dragon reports whether the banker won with a three card 7
+func (o Outcome) Dragon() bool {
+	return o.Winner == Banker && o.BankerCards == 3 && o.BankerTotal == 7
+}
+
+// Panda reports whether the player won with a three card 8
+func (o Outcome) Panda() bool {
+	return o.Winner == Player && o.PlayerCards == 3 && o.PlayerTotal == 8
+}
@@ -0,0 +1,129 @@
+package baccarat
+
+import (
+	"fmt"
+	"strings"
+
+	"casino/utils"
+)
+
+const (
+	// roadRows is how tall the bead plate and big road are
+	roadRows = 6
+	// roadColumns is how many of the latest columns of each road are shown
+	roadColumns = 12
+)
+
+// BeadPlate lists every coup in order, filling each column top to bottom
+func BeadPlate(history []Outcome) []string {
+	columns := (len(history) + roadRows - 1) / roadRows
+	first := max(0, columns-roadColumns)
+
+	lines := make([]string, roadRows)
+	for row := range roadRows {
+		var cells []string
+		for column := first; column < first+roadColumns; column++ {
+			i := column*roadRows + row
+			if i >= len(history) {
+				cells = append(cells, utils.Dim("·"))
+				continue
+			}
+			cells = append(cells, sideCell(history[i].Winner))
+		}
+		lines[row] = strings.Join(cells, " ")
+	}
+
+	return lines
+}
+
+// bigRoadCell is a player or banker win on the big road, with any ties that
+// came straight after it
+type bigRoadCell struct {
+	winner Side
+	ties   int
+}
+
+// BigRoad lists streaks of player and banker wins, a new column for each
+// streak. A streak longer than the road is tall turns right along the bottom.
+// Ties don't get a cell of their own and are counted on the win before them.
+func BigRoad(history []Outcome) []string {
+	grid := map[[2]int]*bigRoadCell{}
+	var last *bigRoadCell
+	var lastPos [2]int // column, row
+	streakColumn := -1
+	leadingTies := 0
+	width := 0
+
+	for _, outcome := range history {
+		if outcome.Winner == Tie {
+			if last == nil {
+				leadingTies++
+			} else {
+				last.ties++
+			}
+			continue
+		}
+
+		cell := &bigRoadCell{winner: outcome.Winner}
+		if last == nil {
+			cell.ties = leadingTies
+		}
+
+		switch {
+		case last == nil || last.winner != outcome.Winner:
+			streakColumn++
+			lastPos = [2]int{streakColumn, 0}
+		case lastPos[1]+1 < roadRows && grid[[2]int{lastPos[0], lastPos[1] + 1}] == nil:
+			lastPos = [2]int{lastPos[0], lastPos[1] + 1}
+		default:
+			// The dragon tail: no room below, so the streak carries on to the right
+			lastPos = [2]int{lastPos[0] + 1, lastPos[1]}
+		}
+
+		grid[lastPos] = cell
+		last = cell
+		width = max(width, lastPos[0]+1)
+	}
+
+	first := max(0, width-roadColumns)
+	lines := make([]string, roadRows)
+	for row := range roadRows {
+		var cells []string
+		for column := first; column < first+roadColumns; column++ {
+			cell, ok := grid[[2]int{column, row}]
+			if !ok {
+				cells = append(cells, utils.Dim("· "))
+				continue
+			}
+
+			ties := " "
+			if cell.ties > 0 {
+				ties = utils.Green("%s", tieCount(cell.ties))
+			}
+			cells = append(cells, sideCell(cell.winner)+ties)
+		}
+		lines[row] = strings.Join(cells, "")
+	}
+
+	return lines
+}
+
+// tieCount fits the number of ties in one character
+func tieCount(ties int) string {
+	if ties > 9 {
+		return "+"
+	}
+
+	return fmt.Sprint(ties)
+}
+
+func sideCell(side Side) string {
+	switch side {
+	case Player:
+		return utils.Blue("P")
+	case Banker:
+		return utils.Red("B")
+	default:
+		return utils.Green("T")
+	}
+}
@@ -0,0 +1,62 @@
+package baccarat
+
+import "casino/entities"
+
+// CardValue is what a card counts for in baccarat: aces are 1, tens and face
+// cards are 0 and everything else is its pip value
+func CardValue(card entities.Card) int {
+	switch card.Rank {
+	case entities.Ten, entities.Jack, entities.Queen, entities.King:
+		return 0
+	case entities.Ace:
+		return 1
+	default:
+		// SortValue counts up from 0 for an ace
+		return card.SortValue + 1
+	}
+}
+
+// HandTotal is the last digit of the sum of the cards
+func HandTotal(cards []entities.Card) int {
+	total := 0
+	for _, card := range cards {
+		total += CardValue(card)
+	}
+
+	return total % 10
+}
+
+// IsNatural reports whether a two card hand is an 8 or 9, which ends the coup
+func IsNatural(cards []entities.Card) bool {
+	return len(cards) == 2 && HandTotal(cards) >= 8
+}
+
+// PlayerDraws reports whether the player hand takes a third card. The player
+// draws on 0 to 5 and stands on 6 or 7.
+func PlayerDraws(playerTotal int) bool {
+	return playerTotal <= 5
+}
+
+// BankerDraws reports whether the banker hand takes a third card. If the player
+// stood the banker draws like the player does. Otherwise it depends on the
+// value of the player's third card, playerThird.
+func BankerDraws(bankerTotal int, playerDrew bool, playerThird int) bool {
+	if !playerDrew {
+		return bankerTotal <= 5
+	}
+
+	switch bankerTotal {
+	case 0, 1, 2:
+		return true
+	case 3:
+		return playerThird != 8
+	case 4:
+		return playerThird >= 2 && playerThird <= 7
+	case 5:
+		return playerThird >= 4 && playerThird <= 7
+	case 6:
+		return playerThird == 6 || playerThird == 7
+	default:
+		return false
+	}
+}
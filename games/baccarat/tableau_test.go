@@ -0,0 +1,94 @@
+package baccarat
+
+import (
+	"testing"
+
+	"casino/entities"
+)
+
+// ranks are the card ranks in SortValue order
+var ranks = []entities.StandardRank{
+	entities.Ace, entities.Two, entities.Three, entities.Four, entities.Five, entities.Six, entities.Seven,
+	entities.Eight, entities.Nine, entities.Ten, entities.Jack, entities.Queen, entities.King,
+}
+
+func hand(cardRanks ...entities.StandardRank) []entities.Card {
+	var cards []entities.Card
+	for _, rank := range cardRanks {
+		for sortValue, r := range ranks {
+			if r == rank {
+				cards = append(cards, entities.Card{Rank: rank, Suit: entities.Spade, SortValue: sortValue})
+			}
+		}
+	}
+
+	return cards
+}
+
+func TestHandTotal(t *testing.T) {
+	tests := []struct {
+		name    string
+		cards   []entities.Card
+		total   int
+		natural bool
+	}{
+		{"ace counts 1", hand(entities.Ace, entities.Five), 6, false},
+		{"tens and faces count 0", hand(entities.Ten, entities.King), 0, false},
+		{"only the last digit counts", hand(entities.Seven, entities.Eight), 5, false},
+		{"natural 9", hand(entities.Queen, entities.Nine), 9, true},
+		{"natural 8 from two fours", hand(entities.Four, entities.Four), 8, true},
+		{"three card 8 isn't a natural", hand(entities.Two, entities.Three, entities.Three), 8, false},
+		{"three cards wrap past 20", hand(entities.Nine, entities.Nine, entities.Nine), 7, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if total := HandTotal(test.cards); total != test.total {
+				t.Errorf("HandTotal = %d, want %d", total, test.total)
+			}
+			if natural := IsNatural(test.cards); natural != test.natural {
+				t.Errorf("IsNatural = %t, want %t", natural, test.natural)
+			}
+		})
+	}
+}
+
+func TestPlayerDraws(t *testing.T) {
+	for total := range 8 {
+		if want := total <= 5; PlayerDraws(total) != want {
+			t.Errorf("PlayerDraws(%d) = %t, want %t", total, !want, want)
+		}
+	}
+}
+
+func TestBankerDraws(t *testing.T) {
+	// The banker's third card rule: the banker's total down the side, the value
+	// of the player's third card, 0 to 9, across the top. D draws, S stands.
+	table := []string{
+		0: "DDDDDDDDDD",
+		1: "DDDDDDDDDD",
+		2: "DDDDDDDDDD",
+		3: "DDDDDDDDSD",
+		4: "SSDDDDDDSS",
+		5: "SSSSDDDDSS",
+		6: "SSSSSSDDSS",
+		7: "SSSSSSSSSS",
+	}
+
+	for bankerTotal, row := range table {
+		for playerThird, rule := range row {
+			want := rule == 'D'
+			if got := BankerDraws(bankerTotal, true, playerThird); got != want {
+				t.Errorf("BankerDraws(%d, player drew %d) = %t, want %t", bankerTotal, playerThird, got, want)
+			}
+		}
+	}
+}
+
+func TestBankerDrawsWhenPlayerStands(t *testing.T) {
+	for total := range 8 {
+		if want := total <= 5; BankerDraws(total, false, 0) != want {
+			t.Errorf("BankerDraws(%d, player stood) = %t, want %t", total, !want, want)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package blackjack
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"casino/entities"
@@ -12,18 +13,40 @@ import (
 
 const DealerStandValue = 17
 
+// playerHand is one of the hands the player is playing. Everyone starts with one
+// hand and gets more by splitting pairs.
+type playerHand struct {
+	hand    entities.Hand
+	wager   int
+	split   bool // the hand came from a split
+	doubled bool
+	settled bool // the hand was paid out early, e.g. by taking even money
+
+	surrendered bool
+}
+
+// splitAces reports whether the hand was made by splitting aces, which only get
+// one more card each
+func (ph playerHand) splitAces() bool {
+	return ph.split && len(ph.hand.Cards) > 0 && ph.hand.Cards[0].Rank == entities.Ace
+}
+
 type blackjack struct {
 	dealer      utils.Dealer
 	saveManager utils.SaveDataManager
+	rules       Rules
+	tableChosen bool
 
-	hands     map[entities.Role]entities.Hand
-	wager     int
-	userChips int
+	dealerHand  entities.Hand
+	playerHands []playerHand
+	current     int // index into playerHands of the hand being played
+	splits      int
+	wager       int // the opening wager, each split hand starts with the same
+	insurance   int
+	userChips   int
 
 	in  chan string
 	out chan string
-
-	quit func()
 }
 
 func NewBlackjack(
@@ -31,158 +54,348 @@ func NewBlackjack(
 	saveManager utils.SaveDataManager,
 	in chan string,
 	out chan string,
-	quit func(),
+	opts ...Option,
 ) games.Game {
-	return &blackjack{
+	b := &blackjack{
 		dealer:      dealer,
 		saveManager: saveManager,
+		rules:       DefaultRules(),
 		in:          in,
 		out:         out,
-		quit:        quit,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.tableChosen {
+		b.useRules(b.rules)
+	}
+
+	return b
 }
 
 func (b *blackjack) Name() string {
 	return "Blackjack"
 }
 
-func (b *blackjack) Play() {
+// Play runs rounds until the player chooses to leave the table. Every round
+// returns here before the next one starts.
+func (b *blackjack) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := b.saveManager.Read().RemainingChips
+
+	for {
+		played, err := b.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = b.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = b.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the player's bet, plays one round and settles it. Returns
+// whether a round was dealt. Once the cards are out the round is always
+// finished and settled, even if ctx is canceled part way through.
+func (b *blackjack) playRound(ctx context.Context) (bool, error) {
 	utils.Clear(b.out)
 	save := b.saveManager.Read()
 	b.userChips = save.RemainingChips
 
 	utils.PrintBanner(b.Name(), b.out)
-	b.out <- fmt.Sprintf("%sDealer stands on %d%s", utils.AnsiDim, DealerStandValue, utils.AnsiReset)
+	if !b.tableChosen {
+		if err := b.chooseTable(ctx); err != nil {
+			return false, err
+		}
+		utils.Clear(b.out)
+		utils.PrintBanner(b.Name(), b.out)
+	}
+	for _, line := range b.rules.Describe() {
+		b.out <- utils.Dim("%s", line)
+	}
+	if b.dealer.NeedsShuffle() {
+		b.out <- utils.Yellow("Cut card is out, shuffling %d decks...", b.dealer.NumDecks())
+		b.dealer.Shuffle()
+	}
 	b.out <- utils.Dim(fmt.Sprintf("You have %d chips remaining", save.RemainingChips))
-	b.wager = utils.GetBet(b.in, b.out, "How much would you like to wager?", 1, save.RemainingChips)
+	wager, err := utils.GetBet(ctx, b.in, b.out, "How much would you like to wager?", 1, save.RemainingChips)
+	if err != nil {
+		return false, err
+	}
+	b.wager = wager
 
-	b.start()
+	if err := b.start(); err != nil {
+		b.misdeal(err)
+		return true, nil
+	}
+	if err := b.run(ctx); err != nil {
+		b.misdeal(err)
+		return true, nil
+	}
+
+	b.endGame()
+	return true, nil
 }
 
-func (b *blackjack) start() {
+// start takes the wager and deals the opening hands
+func (b *blackjack) start() error {
 	b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Wagered %d chips", b.wager)))
 	save := b.saveManager.Read()
 	save.RemainingChips -= b.wager
 	b.userChips = save.RemainingChips
 	b.saveManager.Save(save)
 
-	b.out <- utils.Dim("Shuffling the deck...")
-	b.dealer.Shuffle()
-	b.hands = map[entities.Role]entities.Hand{}
+	b.dealerHand = entities.Hand{}
+	b.playerHands = []playerHand{{wager: b.wager}}
+	b.current = 0
+	b.splits = 0
+	b.insurance = 0
 
 	// Create dealer initial hand and user initial hand
 	for i := range 4 {
-		role := entities.DealerRole
+		hand := &b.dealerHand
 		if i%2 == 0 {
-			role = entities.UserRole
+			hand = &b.playerHands[0].hand
 		}
 
-		hand := b.hands[role]
-		hand.Cards = append(hand.Cards, b.dealer.Draw())
-		b.hands[role] = hand
+		if err := b.draw(hand); err != nil {
+			return err
+		}
 	}
 
-	b.hands[entities.DealerRole].Cards[1].Hidden = true
+	b.dealerHand.Cards[1].Hidden = true
 
 	b.printUpdate()
-	b.run()
+	return nil
 }
 
-func (b *blackjack) run() {
+// run plays out the round from the opening deal until every hand, including
+// the dealer's, is finished. The round is settled afterwards by endGame.
+func (b *blackjack) run(ctx context.Context) error {
 	b.out <- ""
 
-	if b.sumHand(b.hands[entities.UserRole]) == 21 {
+	if b.offerEarlySurrender(ctx) {
+		b.currentHand().surrendered = true
+		b.revealDealer()
+		b.printUpdate()
+		return nil
+	}
+
+	if b.dealerHand.Cards[0].Rank == entities.Ace {
+		b.offerInsurance(ctx)
+	}
+
+	if b.playerHands[0].settled {
+		b.revealDealer()
+		b.printUpdate()
+		b.out <- utils.Green(utils.Bold(fmt.Sprintf("Took even money (+%d chips)", b.playerHands[0].wager*2)))
+		return nil
+	}
+
+	if b.dealerPeek() {
+		b.revealDealer()
+		b.printUpdate()
+		b.out <- utils.Red(utils.Bold("DEALER BLACKJACK"))
+		b.settleInsurance(true)
+		return nil
+	}
+	b.settleInsurance(false)
+
+	if b.isNatural(b.playerHands[0]) {
+		b.revealDealer()
+		b.printUpdate()
 		b.out <- utils.Green(utils.Bold("BLACKJACK"))
-		b.endGame()
-		return
+		return nil
 	}
 
-	b.out <- utils.Cyan("Your move → ") + utils.Bold("Hit (h)") + " / " + utils.Bold("Stay (s)")
+	// Splitting adds hands to the right of the current one, so keep going until
+	// every hand has been played
+	for b.current = 0; b.current < len(b.playerHands); b.current++ {
+		if err := b.playHand(ctx); err != nil {
+			return err
+		}
+	}
+
+	return b.runDealer()
+}
+
+// playHand takes the player's moves for the current hand until it is finished.
+// If the player stops answering the hand stands.
+func (b *blackjack) playHand(ctx context.Context) error {
+	if len(b.currentHand().hand.Cards) == 1 {
+		// Split hands get their second card once it's their turn
+		if err := b.draw(&b.currentHand().hand); err != nil {
+			return err
+		}
+		b.printUpdate()
+	}
+
+	if b.handFinished() {
+		b.announceFinished()
+		return nil
+	}
+
+	b.out <- b.movePrompt()
+
+	for {
+		line, err := utils.ReadLine(ctx, b.in)
+		if err != nil {
+			return nil
+		}
 
-	for line := range b.in {
 		switch strings.ToLower(strings.TrimSpace(line)) {
 		case "hit":
 			fallthrough
 		case "h":
-			bust, err := b.hit(entities.UserRole)
-			if err != nil {
-				b.out <- utils.Red(err.Error())
+			if !b.canHit() {
+				b.out <- utils.Yellow("You can't hit right now")
+				b.out <- b.movePrompt()
+				continue
+			}
+
+			if err := b.draw(&b.currentHand().hand); err != nil {
+				return err
+			}
+			b.printUpdate()
+		case "double":
+			fallthrough
+		case "d":
+			if !b.canDouble() {
+				b.out <- utils.Yellow("You can't double down right now")
+				b.out <- b.movePrompt()
 				continue
 			}
 
+			if err := b.doubleDown(); err != nil {
+				return err
+			}
 			b.printUpdate()
+			b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Doubled down, wagered %d chips", b.currentHand().wager)))
+		case "split":
+			fallthrough
+		case "p":
+			if !b.canSplit() {
+				b.out <- utils.Yellow("You can't split right now")
+				b.out <- b.movePrompt()
+				continue
+			}
 
-			if !bust {
-				b.out <- utils.Cyan("Your move → ") + utils.Bold("Hit (h)") + " / " + utils.Bold("Stay (s)")
+			if err := b.split(); err != nil {
+				return err
+			}
+			b.printUpdate()
+			b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Split, wagered %d more chips", b.currentHand().wager)))
+		case "surrender":
+			fallthrough
+		case "r":
+			if !b.canSurrender() {
+				b.out <- utils.Yellow("You can't surrender right now")
+				b.out <- b.movePrompt()
 				continue
 			}
 
-			b.out <- utils.Red("BUST!")
+			b.currentHand().surrendered = true
+			return nil
 		case "stay":
+			fallthrough
 		case "s":
+			return nil
 		default:
 			b.out <- utils.Yellow(fmt.Sprintf("Unknown command: %s", line))
-			b.out <- utils.Cyan("Your move → ") + utils.Bold("Hit (h)") + " / " + utils.Bold("Stay (s)")
+			b.out <- b.movePrompt()
 			continue
 		}
 
-		break
-	}
-
-	b.runDealer()
-}
+		if b.handFinished() {
+			b.announceFinished()
+			return nil
+		}
 
-func (b *blackjack) runDealer() {
-	if b.sumHand(b.hands[entities.UserRole]) > 21 {
-		b.endGame()
-		return
+		b.out <- b.movePrompt()
 	}
+}
 
-	// Reveal all dealer cards
-	dealerHand := b.hands[entities.DealerRole]
-	dealerHand.Cards = utils.Map(dealerHand.Cards, func(_ int, card entities.Card) entities.Card {
-		card.Hidden = false
-		return card
+// runDealer plays the dealer's hand, unless every player hand is already lost
+func (b *blackjack) runDealer() error {
+	allDone := utils.All(b.playerHands, func(ph playerHand) bool {
+		return ph.surrendered || b.sumHand(ph.hand) > 21
 	})
-	b.hands[entities.DealerRole] = dealerHand
-
-	if b.sumHand(dealerHand) == 21 {
-		b.out <- utils.Red(utils.Bold("DEALER BLACKJACK"))
-		b.printUpdate()
-		b.endGame()
+	if allDone {
+		return nil
 	}
 
-	for b.sumHand(b.hands[entities.DealerRole]) < DealerStandValue {
-		b.hit(entities.DealerRole)
+	b.revealDealer()
+
+	for b.dealerShouldHit() {
+		if err := b.draw(&b.dealerHand); err != nil {
+			return err
+		}
 	}
 
 	b.printUpdate()
 
-	if b.sumHand(b.hands[entities.DealerRole]) > 21 {
+	if b.sumHand(b.dealerHand) > 21 {
 		b.out <- utils.Green("DEALER BUST!")
 	}
 
-	b.endGame()
+	return nil
 }
 
+// endGame pays out every hand against the dealer's and clears the table
 func (b *blackjack) endGame() {
-	dealerHand := b.hands[entities.DealerRole]
-	userHand := b.hands[entities.UserRole]
-	dealerShowing := b.sumHand(dealerHand)
-	userShowing := b.sumHand(userHand)
-
-	userWins := userShowing <= 21 && (userShowing > dealerShowing || dealerShowing > 21)
-	tie := dealerShowing == userShowing
+	dealerShowing := b.sumHand(b.dealerHand)
+	dealerNatural := len(b.dealerHand.Cards) == 2 && dealerShowing == 21
 
 	winnings := 0
-	if userWins {
-		winnings = b.wager * 2
-		b.out <- utils.Green(utils.Bold(fmt.Sprintf("YOU WIN! +%d chips", winnings)))
-	} else if tie {
-		winnings = b.wager
-		b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Tie - win your chips back (+%d chips)", winnings)))
-	} else {
-		b.out <- utils.Red(utils.Bold(fmt.Sprintf("Dealer wins (-%d chips)", b.wager)))
+	var userTotals []string
+	for i, ph := range b.playerHands {
+		userShowing := b.sumHand(ph.hand)
+		userTotals = append(userTotals, fmt.Sprint(userShowing))
+		if ph.settled {
+			continue
+		}
+		if ph.surrendered {
+			refund := ph.wager / 2
+			winnings += refund
+			b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Surrendered, half your wager back (-%d chips)", ph.wager-refund)))
+			continue
+		}
+
+		label := ""
+		if len(b.playerHands) > 1 {
+			label = fmt.Sprintf("Hand %d: ", i+1)
+		}
+
+		userNatural := b.isNatural(ph)
+		userWins := userShowing <= 21 && (userShowing > dealerShowing || dealerShowing > 21)
+		tie := userShowing <= 21 && dealerShowing == userShowing
+		if userNatural || dealerNatural {
+			// A natural beats any other 21, two naturals push
+			userWins = userNatural && !dealerNatural
+			tie = userNatural && dealerNatural
+		}
+
+		if userWins && userNatural {
+			payout := ph.wager + b.rules.BlackjackPays.Winnings(ph.wager)
+			winnings += payout
+			b.out <- utils.Green(utils.Bold(fmt.Sprintf(
+				"%sBLACKJACK PAYS %s! +%d chips", label, b.rules.BlackjackPays, payout,
+			)))
+		} else if userWins {
+			winnings += ph.wager * 2
+			b.out <- utils.Green(utils.Bold(fmt.Sprintf("%sYOU WIN! +%d chips", label, ph.wager*2)))
+		} else if tie {
+			winnings += ph.wager
+			b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("%sTie - win your chips back (+%d chips)", label, ph.wager)))
+		} else {
+			b.out <- utils.Red(utils.Bold(fmt.Sprintf("%sDealer wins (-%d chips)", label, ph.wager)))
+		}
 	}
 
 	stats := b.saveManager.Read()
@@ -191,32 +404,70 @@ func (b *blackjack) endGame() {
 
 	b.out <- fmt.Sprintf("New total: %d", stats.RemainingChips)
 	b.out <- fmt.Sprintf(
-		"%sYou%s: %d\t%sDealer%s: %d",
-		utils.AnsiBold, utils.AnsiReset, userShowing,
+		"%sYou%s: %s\t%sDealer%s: %d",
+		utils.AnsiBold, utils.AnsiReset, strings.Join(userTotals, ", "),
 		utils.AnsiBold, utils.AnsiReset, dealerShowing,
 	)
 
+	b.clearTable()
+}
+
+// misdeal calls off the round when the dealer can't deal, giving back every
+// wager still on the table
+func (b *blackjack) misdeal(err error) {
+	refund := b.insurance
+	for _, ph := range b.playerHands {
+		if !ph.settled {
+			refund += ph.wager
+		}
+	}
+
+	save := b.saveManager.Read()
+	save.RemainingChips += refund
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+
+	b.out <- utils.Red(fmt.Sprintf("Misdeal: %s", err.Error()))
+	b.out <- utils.Yellow(fmt.Sprintf("All wagers returned (+%d chips)", refund))
+	b.out <- fmt.Sprintf("New total: %d", save.RemainingChips)
+
+	b.clearTable()
+	b.dealer.Shuffle()
+}
+
+// clearTable discards every card in play and resets the round's bets
+func (b *blackjack) clearTable() {
 	b.wager = 0
-	// Discard all cards
-	for _, hand := range b.hands {
-		b.dealer.Discard(hand.Cards...)
+	b.insurance = 0
+	b.dealer.Discard(b.dealerHand.Cards...)
+	for _, ph := range b.playerHands {
+		b.dealer.Discard(ph.hand.Cards...)
 	}
-	b.hands = nil
+	b.dealerHand = entities.Hand{}
+	b.playerHands = nil
+}
 
+// playAgain asks whether the player wants another round. Returns false if they
+// want to leave the table.
+func (b *blackjack) playAgain(ctx context.Context) (bool, error) {
 	b.out <- "Play again? " + utils.Bold("Yes (y)") + " or " + utils.Bold("no (n)")
 
-	for line := range b.in {
+	for {
+		line, err := utils.ReadLine(ctx, b.in)
+		if err != nil {
+			return false, err
+		}
+
 		lowerLine := strings.ToLower(line)
 		switch lowerLine {
 		case "yes":
 			fallthrough
 		case "y":
-			b.Play()
+			return true, nil
 		case "no":
 			fallthrough
 		case "n":
-			b.quit()
-			return
+			return false, nil
 		default:
 			b.out <- utils.Yellow(fmt.Sprintf("Unknown command: %s", line))
 			b.out <- "Play again?" + utils.Bold("Yes (y)") + "or " + utils.Bold("no (n)")
@@ -224,45 +475,364 @@ func (b *blackjack) endGame() {
 	}
 }
 
-// hit allows the player to draw another card.
-// Returns true if the hand busts
-func (b *blackjack) hit(role entities.Role) (bool, error) {
-	if b.hands == nil {
-		return false, errors.New("No hand!")
+// offerInsurance is called when the dealer shows an ace. A player holding a
+// natural is offered even money, everyone else may take insurance of up to half
+// their wager. If the player stops answering the offer is declined.
+func (b *blackjack) offerInsurance(ctx context.Context) {
+	ph := b.currentHand()
+	if b.isNatural(*ph) {
+		choice, _ := utils.GetInput(
+			ctx,
+			b.in,
+			b.out,
+			[]string{"yes", "y", "no", "n"},
+			"Dealer shows an ace. Take even money? "+utils.Bold("Yes (y)")+" or "+utils.Bold("no (n)"),
+		)
+		if choice != "yes" && choice != "y" {
+			return
+		}
+
+		save := b.saveManager.Read()
+		save.RemainingChips += ph.wager * 2
+		b.saveManager.Save(save)
+		b.userChips = save.RemainingChips
+		ph.settled = true
+		return
+	}
+
+	maxInsurance := min(ph.wager/2, b.userChips)
+	if maxInsurance == 0 {
+		return
+	}
+
+	insurance, err := utils.GetBet(
+		ctx,
+		b.in,
+		b.out,
+		fmt.Sprintf("Dealer shows an ace. Insurance pays 2 to 1, how much? (0 to %d)", maxInsurance),
+		0,
+		maxInsurance,
+	)
+	if err != nil || insurance == 0 {
+		return
+	}
+
+	b.insurance = insurance
+	save := b.saveManager.Read()
+	save.RemainingChips -= b.insurance
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+	b.out <- utils.Yellow(utils.Bold(fmt.Sprintf("Insured for %d chips", b.insurance)))
+}
+
+// offerEarlySurrender lets the player surrender before the dealer checks for
+// blackjack, when the table allows it. Returns true if the player surrendered.
+func (b *blackjack) offerEarlySurrender(ctx context.Context) bool {
+	if b.rules.Surrender != SurrenderEarly || b.dealerHand.Cards[0].Value < 10 || b.isNatural(*b.currentHand()) {
+		return false
+	}
+
+	choice, _ := utils.GetInput(
+		ctx,
+		b.in,
+		b.out,
+		[]string{"surrender", "r", "continue", "c"},
+		"Surrender before the dealer checks for blackjack? "+utils.Bold("Surrender (r)")+" or "+utils.Bold("continue (c)"),
+	)
+
+	return choice == "surrender" || choice == "r"
+}
+
+// settleInsurance pays out or takes the insurance bet once the dealer has peeked
+func (b *blackjack) settleInsurance(dealerBlackjack bool) {
+	if b.insurance == 0 {
+		return
+	}
+
+	if dealerBlackjack {
+		payout := b.insurance * 3
+		save := b.saveManager.Read()
+		save.RemainingChips += payout
+		b.saveManager.Save(save)
+		b.userChips = save.RemainingChips
+		b.out <- utils.Green(utils.Bold(fmt.Sprintf("Insurance pays 2 to 1 (+%d chips)", payout)))
+	} else {
+		b.out <- utils.Red(fmt.Sprintf("Dealer does not have blackjack, insurance lost (-%d chips)", b.insurance))
+	}
+
+	b.insurance = 0
+}
+
+// dealerPeek checks the dealer's hole card for blackjack when the up card is an
+// ace or worth ten, before the player acts
+func (b *blackjack) dealerPeek() bool {
+	if b.dealerHand.Cards[0].Value < 10 {
+		return false
+	}
+
+	b.out <- utils.Dim("Dealer checks for blackjack...")
+	hand := entities.Hand{Cards: slices.Clone(b.dealerHand.Cards)}
+	for i := range hand.Cards {
+		hand.Cards[i].Hidden = false
+	}
+
+	return b.sumHand(hand) == 21
+}
+
+// revealDealer turns over the dealer's hole card
+func (b *blackjack) revealDealer() {
+	b.dealerHand.Cards = utils.Map(b.dealerHand.Cards, func(_ int, card entities.Card) entities.Card {
+		card.Hidden = false
+		return card
+	})
+}
+
+// chooseTable asks the player which table they want to sit at
+func (b *blackjack) chooseTable(ctx context.Context) error {
+	b.out <- "Pick a table:"
+	var choices []string
+	for i, table := range Tables {
+		choice := fmt.Sprint(i + 1)
+		choices = append(choices, choice)
+		b.out <- fmt.Sprintf("%s. %s", choice, table.Name)
+	}
+
+	choice, err := utils.GetInput(ctx, b.in, b.out, choices, "Which table? ("+strings.Join(choices, ", ")+")")
+	if err != nil {
+		return err
+	}
+	for i, c := range choices {
+		if c == choice {
+			b.useRules(Tables[i].Rules)
+		}
+	}
+	b.tableChosen = true
+
+	return nil
+}
+
+// useRules switches the game to rules and builds a fresh shoe to match
+func (b *blackjack) useRules(rules Rules) {
+	b.rules = rules
+	b.dealer = utils.NewShoe(entities.ShuffleOpts{
+		NumDecks:          rules.Decks,
+		Penetration:       rules.Penetration,
+		ReshuffleDiscards: true,
+	})
+}
+
+// dealerShouldHit reports whether the dealer has to draw another card
+func (b *blackjack) dealerShouldHit() bool {
+	total, soft := b.handTotal(b.dealerHand)
+	if total < DealerStandValue {
+		return true
+	}
+
+	return total == DealerStandValue && soft && b.rules.DealerHitsSoft17
+}
+
+// draw deals the next card into hand
+func (b *blackjack) draw(hand *entities.Hand) error {
+	card, err := b.dealer.Draw()
+	if err != nil {
+		return err
+	}
+
+	hand.Cards = append(hand.Cards, card)
+	return nil
+}
+
+// isNatural reports whether the hand is a blackjack: 21 with the first two cards
+// of a hand that wasn't split
+func (b *blackjack) isNatural(ph playerHand) bool {
+	return !ph.split && len(ph.hand.Cards) == 2 && b.sumHand(ph.hand) == 21
+}
+
+func (b *blackjack) currentHand() *playerHand {
+	return &b.playerHands[b.current]
+}
+
+// handFinished reports whether the current hand can't take any more moves
+func (b *blackjack) handFinished() bool {
+	ph := b.currentHand()
+	total := b.sumHand(ph.hand)
+	if total >= 21 || ph.doubled {
+		return true
+	}
+
+	return ph.splitAces() && !b.canSplit()
+}
+
+// announceFinished tells the player why their hand ended without them standing
+func (b *blackjack) announceFinished() {
+	ph := b.currentHand()
+	switch {
+	case b.sumHand(ph.hand) > 21:
+		b.out <- utils.Red("BUST!")
+	case ph.splitAces() && !ph.doubled && b.sumHand(ph.hand) < 21:
+		b.out <- utils.Dim("Split aces only get one card")
 	}
+}
+
+func (b *blackjack) canHit() bool {
+	return !b.currentHand().splitAces()
+}
+
+// canDouble reports whether the player is allowed to double down on their hand
+func (b *blackjack) canDouble() bool {
+	ph := b.currentHand()
+	if len(ph.hand.Cards) != 2 || b.userChips < ph.wager || ph.splitAces() {
+		return false
+	}
+	if ph.split && !b.rules.DoubleAfterSplit {
+		return false
+	}
+
+	switch b.rules.Double {
+	case DoubleNineToEleven:
+		total := b.sumHand(ph.hand)
+		return total >= 9 && total <= 11
+	default:
+		return true
+	}
+}
+
+// canSurrender reports whether the player may surrender, which is only allowed as
+// their first decision
+func (b *blackjack) canSurrender() bool {
+	ph := b.currentHand()
+	return b.rules.Surrender != SurrenderNone &&
+		len(b.playerHands) == 1 &&
+		!ph.split &&
+		len(ph.hand.Cards) == 2
+}
+
+// doubleDown doubles the wager on the current hand and deals it exactly one
+// more card
+func (b *blackjack) doubleDown() error {
+	ph := b.currentHand()
 
-	hand := b.hands[role]
-	hand.Cards = append(hand.Cards, b.dealer.Draw())
-	b.hands[role] = hand
+	save := b.saveManager.Read()
+	save.RemainingChips -= ph.wager
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
 
-	return b.sumHand(hand) > 21, nil
+	ph.wager *= 2
+	ph.doubled = true
+	return b.draw(&ph.hand)
+}
+
+// canSplit reports whether the player is allowed to split their current hand
+func (b *blackjack) canSplit() bool {
+	ph := b.currentHand()
+	if len(ph.hand.Cards) != 2 || b.splits >= b.rules.MaxSplits || b.userChips < ph.wager {
+		return false
+	}
+	if ph.hand.Cards[0].Rank != ph.hand.Cards[1].Rank {
+		return false
+	}
+
+	return !ph.splitAces() || b.rules.ResplitAces
+}
+
+// split moves the second card of the current hand into a new hand with the same
+// wager, placed just to the right, and deals the current hand its second card
+func (b *blackjack) split() error {
+	ph := b.currentHand()
+
+	save := b.saveManager.Read()
+	save.RemainingChips -= ph.wager
+	b.saveManager.Save(save)
+	b.userChips = save.RemainingChips
+
+	newHand := playerHand{
+		hand:  entities.Hand{Cards: []entities.Card{ph.hand.Cards[1]}},
+		wager: ph.wager,
+		split: true,
+	}
+	ph.hand.Cards = ph.hand.Cards[:1]
+	ph.split = true
+	b.splits++
+
+	b.playerHands = append(b.playerHands, playerHand{})
+	copy(b.playerHands[b.current+2:], b.playerHands[b.current+1:])
+	b.playerHands[b.current+1] = newHand
+
+	return b.draw(&b.currentHand().hand)
+}
+
+// movePrompt lists the actions the player can take right now
+func (b *blackjack) movePrompt() string {
+	var moves []string
+	if b.canHit() {
+		moves = append(moves, utils.Bold("Hit (h)"))
+	}
+	moves = append(moves, utils.Bold("Stay (s)"))
+	if b.canDouble() {
+		moves = append(moves, utils.Bold("Double (d)"))
+	}
+	if b.canSplit() {
+		moves = append(moves, utils.Bold("Split (p)"))
+	}
+	if b.canSurrender() {
+		moves = append(moves, utils.Bold("Surrender (r)"))
+	}
+
+	prompt := utils.Cyan("Your move → ")
+	if len(b.playerHands) > 1 {
+		prompt = utils.Cyan(fmt.Sprintf("Hand %d → ", b.current+1))
+	}
+
+	return prompt + strings.Join(moves, " / ")
 }
 
 func (b blackjack) printUpdate() {
 	utils.Clear(b.out)
 
+	totalWager := 0
+	for _, ph := range b.playerHands {
+		totalWager += ph.wager
+	}
+
 	b.out <- utils.Dim(fmt.Sprintf("Your chips: %d", b.userChips))
-	b.out <- utils.Dim(fmt.Sprintf("Wagered: %d", b.wager))
+	b.out <- utils.Dim(fmt.Sprintf("Wagered: %d", totalWager))
+	b.out <- utils.Dim(fmt.Sprintf("Shoe: %d cards to the cut card", b.dealer.Remaining()))
+	if b.insurance > 0 {
+		b.out <- utils.Dim(fmt.Sprintf("Insurance: %d", b.insurance))
+	}
 	b.out <- utils.Divider()
 
-	dealerHand := b.hands[entities.DealerRole]
-	userHand := b.hands[entities.UserRole]
-
-	dealterShowing := b.sumHand(dealerHand)
-	userShowing := b.sumHand(userHand)
-
-	if dealerHand.HasHidden() {
-		b.out <- utils.Bold("Dealer") + fmt.Sprintf("\t(showing %d)", dealterShowing)
+	dealerShowing := b.sumHand(b.dealerHand)
+	if b.dealerHand.HasHidden() {
+		b.out <- utils.Bold("Dealer") + fmt.Sprintf("\t(showing %d)", dealerShowing)
 	} else {
-		b.out <- utils.Bold("Dealer") + fmt.Sprintf("\t(total %d)", dealterShowing)
+		b.out <- utils.Bold("Dealer") + fmt.Sprintf("\t(total %d)", dealerShowing)
 	}
-	for _, line := range utils.RenderHand(dealerHand) {
+	for _, line := range utils.RenderHand(b.dealerHand) {
 		b.out <- line
 	}
 
 	b.out <- ""
-	b.out <- utils.Bold("You") + fmt.Sprintf("\t(total %d)", userShowing)
-	for _, line := range utils.RenderHand(userHand) {
+
+	// Lay the player's hands out left to right in the order they're played
+	var columns [][]string
+	for i, ph := range b.playerHands {
+		title := utils.Bold("You")
+		if len(b.playerHands) > 1 {
+			title = utils.Bold(fmt.Sprintf("Hand %d", i+1))
+			if i == b.current {
+				title = utils.Cyan("▶ ") + title
+			}
+		}
+
+		column := []string{
+			title + fmt.Sprintf(" (total %d)", b.sumHand(ph.hand)),
+			utils.Dim(fmt.Sprintf("Wager: %d", ph.wager)),
+		}
+		column = append(column, utils.RenderHand(ph.hand)...)
+		columns = append(columns, column)
+	}
+	for _, line := range utils.JoinColumns("   ", columns...) {
 		b.out <- line
 	}
 
@@ -270,7 +840,13 @@ func (b blackjack) printUpdate() {
 }
 
 func (b blackjack) sumHand(hand entities.Hand) int {
-	total := 0
+	total, _ := b.handTotal(hand)
+	return total
+}
+
+// handTotal adds up the visible cards in hand, counting aces as 11 where that
+// doesn't bust. soft is true when an ace is still being counted as 11.
+func (b blackjack) handTotal(hand entities.Hand) (total int, soft bool) {
 	nAces := 0
 	for _, card := range hand.Cards {
 		if card.Hidden {
@@ -287,5 +863,5 @@ func (b blackjack) sumHand(hand entities.Hand) int {
 		total -= 10
 	}
 
-	return total
+	return total, nAces > 0
 }
@@ -0,0 +1,294 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DoubleRule controls which starting hands the player may double down on
+type DoubleRule int
+
+const (
+	// DoubleAnyTwo allows doubling on any first two cards
+	DoubleAnyTwo DoubleRule = iota
+	// DoubleNineToEleven only allows doubling on a total of 9, 10 or 11
+	DoubleNineToEleven
+)
+
+var doubleRuleNames = map[DoubleRule]string{
+	DoubleAnyTwo:       "any-two",
+	DoubleNineToEleven: "9-11",
+}
+
+func (d DoubleRule) MarshalText() ([]byte, error) {
+	return []byte(doubleRuleNames[d]), nil
+}
+
+func (d *DoubleRule) UnmarshalText(text []byte) error {
+	for rule, name := range doubleRuleNames {
+		if name == string(text) {
+			*d = rule
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown double rule %q, expected \"any-two\" or \"9-11\"", text)
+}
+
+// SurrenderRule controls when the player may give up half their wager to end
+// the hand
+type SurrenderRule int
+
+const (
+	SurrenderNone SurrenderRule = iota
+	// SurrenderLate allows surrendering as the first decision, after the dealer
+	// has checked for blackjack
+	SurrenderLate
+	// SurrenderEarly also allows surrendering before the dealer checks for blackjack
+	SurrenderEarly
+)
+
+var surrenderRuleNames = map[SurrenderRule]string{
+	SurrenderNone:  "none",
+	SurrenderLate:  "late",
+	SurrenderEarly: "early",
+}
+
+func (s SurrenderRule) MarshalText() ([]byte, error) {
+	return []byte(surrenderRuleNames[s]), nil
+}
+
+func (s *SurrenderRule) UnmarshalText(text []byte) error {
+	for rule, name := range surrenderRuleNames {
+		if name == string(text) {
+			*s = rule
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown surrender rule %q, expected \"none\", \"late\" or \"early\"", text)
+}
+
+// Payout is how much a winning bet pays, e.g. 3 to 2
+type Payout struct {
+	Win   int
+	Stake int
+}
+
+var (
+	ThreeToTwo = Payout{Win: 3, Stake: 2}
+	SixToFive  = Payout{Win: 6, Stake: 5}
+)
+
+// Winnings is how much a wager wins at this payout, not including the wager itself.
+// Fractions of a chip are rounded down.
+func (p Payout) Winnings(wager int) int {
+	return wager * p.Win / p.Stake
+}
+
+func (p Payout) String() string {
+	return fmt.Sprintf("%d to %d", p.Win, p.Stake)
+}
+
+// MarshalText writes the payout as "3:2"
+func (p Payout) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d:%d", p.Win, p.Stake), nil
+}
+
+func (p *Payout) UnmarshalText(text []byte) error {
+	var payout Payout
+	if _, err := fmt.Sscanf(string(text), "%d:%d", &payout.Win, &payout.Stake); err != nil {
+		return fmt.Errorf("payout %q should look like \"3:2\"", text)
+	}
+	if payout.Win <= 0 || payout.Stake <= 0 {
+		return fmt.Errorf("payout %q must be positive", text)
+	}
+
+	*p = payout
+	return nil
+}
+
+// Rules are the table rules the blackjack game is played with
+type Rules struct {
+	// DealerHitsSoft17 makes the dealer draw to a soft 17 instead of standing
+	DealerHitsSoft17 bool `json:"dealerHitsSoft17"`
+	// Decks is how many 52 card decks are shuffled together
+	Decks int `json:"decks"`
+	// Penetration is the fraction of the shoe dealt before it is reshuffled
+	Penetration float64 `json:"penetration"`
+
+	Double DoubleRule `json:"double"`
+	// DoubleAfterSplit allows doubling down on a hand that came from a split
+	DoubleAfterSplit bool `json:"doubleAfterSplit"`
+	// MaxSplits is how many times the player may split in one round, so the
+	// player can end up holding MaxSplits+1 hands. 0 disables splitting.
+	MaxSplits int `json:"maxSplits"`
+	// ResplitAces allows splitting again when a split ace is dealt another ace
+	ResplitAces bool          `json:"resplitAces"`
+	Surrender   SurrenderRule `json:"surrender"`
+	// BlackjackPays is the payout for a natural blackjack
+	BlackjackPays Payout `json:"blackjackPays"`
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		DealerHitsSoft17: false,
+		Decks:            6,
+		Penetration:      0.75,
+		Double:           DoubleAnyTwo,
+		DoubleAfterSplit: true,
+		MaxSplits:        3,
+		ResplitAces:      false,
+		Surrender:        SurrenderLate,
+		BlackjackPays:    ThreeToTwo,
+	}
+}
+
+// Table is a named set of rules the player can pick from
+type Table struct {
+	Name  string
+	Rules Rules
+}
+
+// Tables are the tables offered to the player when no rules were configured
+var Tables = []Table{
+	{
+		Name:  "Classic (6 decks, S17, 3:2)",
+		Rules: DefaultRules(),
+	},
+	{
+		Name: "Downtown (2 decks, H17, 3:2)",
+		Rules: Rules{
+			DealerHitsSoft17: true,
+			Decks:            2,
+			Penetration:      0.65,
+			Double:           DoubleAnyTwo,
+			DoubleAfterSplit: true,
+			MaxSplits:        3,
+			Surrender:        SurrenderNone,
+			BlackjackPays:    ThreeToTwo,
+		},
+	},
+	{
+		Name: "Single deck (1 deck, H17, 6:5)",
+		Rules: Rules{
+			DealerHitsSoft17: true,
+			Decks:            1,
+			Penetration:      0.5,
+			Double:           DoubleNineToEleven,
+			DoubleAfterSplit: false,
+			MaxSplits:        1,
+			Surrender:        SurrenderNone,
+			BlackjackPays:    SixToFive,
+		},
+	},
+	{
+		Name: "European (8 decks, S17, early surrender)",
+		Rules: Rules{
+			Decks:            8,
+			Penetration:      0.8,
+			Double:           DoubleNineToEleven,
+			DoubleAfterSplit: true,
+			MaxSplits:        1,
+			Surrender:        SurrenderEarly,
+			BlackjackPays:    ThreeToTwo,
+		},
+	},
+}
+
+// LoadRules reads table rules from a JSON file. Anything missing from the file
+// keeps its default value.
+func LoadRules(path string) (Rules, error) {
+	rules := DefaultRules()
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+	if err := json.Unmarshal(bytes, &rules); err != nil {
+		return rules, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return rules, rules.Validate()
+}
+
+// Validate checks that the rules describe a table that can be played
+func (r Rules) Validate() error {
+	if r.Decks < 1 || r.Decks > 8 {
+		return fmt.Errorf("decks must be between 1 and 8, got %d", r.Decks)
+	}
+	if r.Penetration <= 0 || r.Penetration > 0.9 {
+		return fmt.Errorf("penetration must be above 0 and at most 0.9, got %g", r.Penetration)
+	}
+	if r.MaxSplits < 0 {
+		return fmt.Errorf("maxSplits can't be negative, got %d", r.MaxSplits)
+	}
+	if r.BlackjackPays.Win <= 0 || r.BlackjackPays.Stake <= 0 {
+		return fmt.Errorf("blackjackPays must be positive, got %s", r.BlackjackPays)
+	}
+
+	return nil
+}
+
+// Describe lists the rules in a form that can be shown to the player
+func (r Rules) Describe() []string {
+	var lines []string
+
+	decks := fmt.Sprintf("%d decks", r.Decks)
+	if r.Decks == 1 {
+		decks = "1 deck"
+	}
+	lines = append(lines, fmt.Sprintf("%s, reshuffled after %.0f%% is dealt", decks, r.Penetration*100))
+
+	if r.DealerHitsSoft17 {
+		lines = append(lines, fmt.Sprintf("Dealer hits soft %d", DealerStandValue))
+	} else {
+		lines = append(lines, fmt.Sprintf("Dealer stands on all %ds", DealerStandValue))
+	}
+
+	lines = append(lines, fmt.Sprintf("Blackjack pays %s, insurance pays 2 to 1", r.BlackjackPays))
+
+	double := "Double on any two cards"
+	if r.Double == DoubleNineToEleven {
+		double = "Double on 9, 10 or 11 only"
+	}
+	if r.DoubleAfterSplit {
+		double += ", double after split"
+	} else {
+		double += ", no double after split"
+	}
+	lines = append(lines, double)
+
+	switch {
+	case r.MaxSplits == 0:
+		lines = append(lines, "No splitting")
+	case r.ResplitAces:
+		lines = append(lines, fmt.Sprintf("Split up to %d hands, aces may be resplit", r.MaxSplits+1))
+	default:
+		lines = append(lines, fmt.Sprintf("Split up to %d hands, split aces get one card", r.MaxSplits+1))
+	}
+
+	switch r.Surrender {
+	case SurrenderLate:
+		lines = append(lines, "Late surrender")
+	case SurrenderEarly:
+		lines = append(lines, "Early surrender")
+	default:
+		lines = append(lines, "No surrender")
+	}
+
+	return lines
+}
+
+// Option configures a blackjack game
+type Option func(*blackjack)
+
+// WithRules sets the table rules for a blackjack game. The player won't be asked
+// to pick a table.
+func WithRules(rules Rules) Option {
+	return func(b *blackjack) {
+		b.rules = rules
+		b.tableChosen = true
+	}
+}
@@ -0,0 +1,276 @@
+package craps
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"casino/utils"
+)
+
+// betWords are the words the player can use for each kind of bet
+var betWords = map[string]BetKind{
+	"pass":     PassLine,
+	"dontpass": DontPass,
+	"dp":       DontPass,
+	"come":     Come,
+	"dontcome": DontCome,
+	"dc":       DontCome,
+	"place":    Place,
+	"field":    Field,
+	"hard":     Hardway,
+}
+
+// BetHelp shows the player how to write bets
+var BetHelp = []string{
+	"pass 10          pass line, on the come out roll (1 to 1)",
+	"dp 10            don't pass, on the come out roll (1 to 1, 12 pushes)",
+	"come 10          come, once there's a point (dc for don't come)",
+	"odds pass 20     odds behind your pass line bet, also odds dp, odds come 6, odds dc 6",
+	"place 6 12       place a number: 4 and 10 pay 9 to 5, 5 and 9 7 to 5, 6 and 8 7 to 6",
+	"field 5          one roll on 2, 3, 4, 9, 10, 11 or 12 (2 pays double, 12 triple)",
+	"hard 8 5         8 the hard way, 4-4, before a 7 or easy 8 (4 and 10 pay 7 to 1, 6 and 8 9 to 1)",
+	"down place 6     take a bet down, e.g. down hard 8, down odds pass, down dp",
+}
+
+// parseBet reads where a bet goes, e.g. ["place", "6"], and checks it can be
+// made right now
+func (c *craps) parseBet(words []string) (BetKind, int, error) {
+	kind, ok := betWords[words[0]]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown bet %q, type help to see the bets", words[0])
+	}
+
+	number := 0
+	needsNumber := kind == Place || kind == Hardway
+	if needsNumber != (len(words) == 2) || len(words) > 2 {
+		if needsNumber {
+			return 0, 0, fmt.Errorf("say which number, e.g. %s 6", words[0])
+		}
+		return 0, 0, fmt.Errorf("unknown bet %q", words)
+	}
+	if needsNumber {
+		n, err := strconv.Atoi(words[1])
+		if err != nil || !slices.Contains(Points, n) {
+			return 0, 0, fmt.Errorf("%s bets go on 4, 5, 6, 8, 9 or 10", words[0])
+		}
+		if kind == Hardway && n%2 == 1 {
+			return 0, 0, fmt.Errorf("only 4, 6, 8 and 10 can be rolled the hard way")
+		}
+		number = n
+	}
+
+	switch {
+	case (kind == PassLine || kind == DontPass) && c.point != 0:
+		return 0, 0, fmt.Errorf("line bets go down on the come out roll, try come or dc instead")
+	case (kind == Come || kind == DontCome) && c.point == 0:
+		return 0, 0, fmt.Errorf("come bets go down once there's a point, try pass or dp instead")
+	}
+
+	return kind, number, nil
+}
+
+// findBet finds the player's bet of a kind on a number. Come bets still in the
+// come box are on 0.
+func (c *craps) findBet(kind BetKind, number int) *bet {
+	for _, b := range c.bets {
+		if b.kind == kind && b.number == number {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// parseOddsTarget reads which bet odds go behind, e.g. ["pass"] or ["come", "6"]
+func (c *craps) parseOddsTarget(words []string) (*bet, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("say which bet the odds go behind, e.g. odds pass 20")
+	}
+
+	kind, ok := betWords[words[0]]
+	if !ok || (kind != PassLine && kind != DontPass && kind != Come && kind != DontCome) {
+		return nil, fmt.Errorf("odds go behind pass, dp, come or dc bets")
+	}
+
+	number := 0
+	if kind == Come || kind == DontCome {
+		if len(words) != 2 {
+			return nil, fmt.Errorf("say which number the come bet is on, e.g. odds come 6")
+		}
+		number, _ = strconv.Atoi(words[1])
+	}
+
+	target := c.findBet(kind, number)
+	if target == nil || target.pointFor(c.point) == 0 {
+		return nil, fmt.Errorf("you need a %s bet with a point to put odds behind", BetKindToString[kind])
+	}
+
+	return target, nil
+}
+
+// maxOdds is the most the player can put behind a bet
+func (c *craps) maxOdds(b *bet) int {
+	point := b.pointFor(c.point)
+	if b.kind == DontPass || b.kind == DontCome {
+		// Laying odds, so the limit is on what the odds can win
+		lay := layOdds[point]
+		return c.oddsMultiple * b.amount * lay.stake / lay.win
+	}
+
+	return c.oddsMultiple * b.amount
+}
+
+// pointFor is the number a line or come bet needs, or 0 if it doesn't have one
+// yet. Pass and don't pass bets follow the table's point.
+func (b *bet) pointFor(tablePoint int) int {
+	if b.kind == PassLine || b.kind == DontPass {
+		return tablePoint
+	}
+
+	return b.number
+}
+
+// name describes a bet, e.g. "Place 6" or "Come on 9"
+func (b *bet) name() string {
+	switch {
+	case b.kind == Place || b.kind == Hardway:
+		return fmt.Sprintf("%s %d", BetKindToString[b.kind], b.number)
+	case (b.kind == Come || b.kind == DontCome) && b.number != 0:
+		return fmt.Sprintf("%s on %d", BetKindToString[b.kind], b.number)
+	default:
+		return BetKindToString[b.kind]
+	}
+}
+
+// resolve settles every bet the roll decides and moves the point. Returns how
+// many chips go back to the player, including the bets that won, and what
+// happened to each bet.
+func (c *craps) resolve(faces []int) (int, []string) {
+	total := faces[0] + faces[1]
+	hard := faces[0] == faces[1]
+	comeOut := c.point == 0
+
+	returned := 0
+	var messages []string
+	win := func(b *bet, flat payout, odds payout) {
+		won := flat.winnings(b.amount) + odds.winnings(b.odds)
+		returned += b.amount + b.odds + won
+		messages = append(messages, utils.Green("%s wins (+%d chips)", b.name(), won))
+	}
+	lose := func(b *bet) {
+		messages = append(messages, utils.Red("%s loses (-%d chips)", b.name(), b.amount+b.odds))
+	}
+
+	var stillUp []*bet
+	for _, b := range c.bets {
+		done := true
+		switch b.kind {
+		case PassLine, Come:
+			point := b.pointFor(c.point)
+			switch {
+			case point == 0 && (total == 7 || total == 11):
+				win(b, evenMoney, evenMoney)
+			case point == 0 && (total == 2 || total == 3 || total == 12):
+				lose(b)
+			case point == 0:
+				b.number = total // a pass bet just follows the new table point
+				done = false
+			case total == point && b.kind == Come && comeOut:
+				// Odds on come bets are off on the come out roll
+				returned += b.odds
+				b.odds = 0
+				win(b, evenMoney, evenMoney)
+			case total == point:
+				win(b, evenMoney, trueOdds[point])
+			case total == 7 && b.kind == Come && comeOut:
+				returned += b.odds
+				messages = append(messages, utils.Red("%s loses (-%d chips), odds returned", b.name(), b.amount))
+			case total == 7:
+				lose(b)
+			default:
+				done = false
+			}
+		case DontPass, DontCome:
+			point := b.pointFor(c.point)
+			switch {
+			case point == 0 && (total == 2 || total == 3):
+				win(b, evenMoney, evenMoney)
+			case point == 0 && (total == 7 || total == 11):
+				lose(b)
+			case point == 0 && total == 12:
+				messages = append(messages, utils.Yellow("%s pushes on 12", b.name()))
+				done = false
+			case point == 0:
+				b.number = total
+				done = false
+			case total == 7:
+				win(b, evenMoney, layOdds[point])
+			case total == point:
+				lose(b)
+			default:
+				done = false
+			}
+		case Place:
+			switch {
+			case comeOut:
+				// Place bets are off on the come out roll
+				done = false
+			case total == b.number:
+				won := placePays[b.number].winnings(b.amount)
+				returned += won
+				messages = append(messages, utils.Green("%s pays %d, still up", b.name(), won))
+				done = false
+			case total == 7:
+				lose(b)
+			default:
+				done = false
+			}
+		case Hardway:
+			switch {
+			case comeOut:
+				done = false
+			case total == b.number && hard:
+				won := hardwayPays[b.number].winnings(b.amount)
+				returned += won
+				messages = append(messages, utils.Green("%s pays %d, still up", b.name(), won))
+				done = false
+			case total == b.number || total == 7:
+				lose(b)
+			default:
+				done = false
+			}
+		case Field:
+			if !slices.Contains(fieldNumbers, total) {
+				lose(b)
+				break
+			}
+			pays, ok := fieldPays[total]
+			if !ok {
+				pays = evenMoney
+			}
+			win(b, pays, evenMoney)
+		}
+
+		if !done {
+			stillUp = append(stillUp, b)
+		}
+	}
+	c.bets = stillUp
+
+	// Pass and don't pass bets take their point from the table, not the bet
+	for _, b := range c.bets {
+		if b.kind == PassLine || b.kind == DontPass {
+			b.number = 0
+		}
+	}
+
+	switch {
+	case comeOut && slices.Contains(Points, total):
+		c.point = total
+	case !comeOut && (total == c.point || total == 7):
+		c.point = 0
+	}
+
+	return returned, messages
+}
@@ -0,0 +1,72 @@
+package craps
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		point int // the table's point before the roll, 0 on the come out
+		bet   bet
+		faces []int
+
+		returned int  // chips given back to the player, bets included
+		up       bool // whether the bet is still on the table
+		number   int  // the bet's number if it's still up
+		newPoint int
+	}{
+		{"come loses on 2", 6, bet{kind: Come, amount: 10}, []int{1, 1}, 0, false, 0, 6},
+		{"come loses on 3", 6, bet{kind: Come, amount: 10}, []int{1, 2}, 0, false, 0, 6},
+		{"come loses on 12", 6, bet{kind: Come, amount: 10}, []int{6, 6}, 0, false, 0, 6},
+		{"come wins on 11", 6, bet{kind: Come, amount: 10}, []int{5, 6}, 20, false, 0, 6},
+		{"come wins on the seven out", 6, bet{kind: Come, amount: 10}, []int{3, 4}, 20, false, 0, 0},
+		{"come moves to its point", 6, bet{kind: Come, amount: 10}, []int{2, 3}, 0, true, 5, 6},
+		{"don't come wins on 2", 6, bet{kind: DontCome, amount: 10}, []int{1, 1}, 20, false, 0, 6},
+		{"don't come wins on 3", 6, bet{kind: DontCome, amount: 10}, []int{1, 2}, 20, false, 0, 6},
+		{"don't come pushes on 12", 6, bet{kind: DontCome, amount: 10}, []int{6, 6}, 0, true, 0, 6},
+		{"don't come loses on 11", 6, bet{kind: DontCome, amount: 10}, []int{5, 6}, 0, false, 0, 6},
+		{"don't come moves to its point", 6, bet{kind: DontCome, amount: 10}, []int{1, 3}, 0, true, 4, 6},
+		{"come point made with odds", 6, bet{kind: Come, number: 9, amount: 10, odds: 20}, []int{4, 5}, 70, false, 0, 6},
+		{"come point sevens out with odds", 6, bet{kind: Come, number: 9, amount: 10, odds: 20}, []int{3, 4}, 0, false, 0, 0},
+		{"come odds are off on the come out win", 0, bet{kind: Come, number: 9, amount: 10, odds: 20}, []int{4, 5}, 40, false, 0, 9},
+		{"come odds are off on the come out seven", 0, bet{kind: Come, number: 9, amount: 10, odds: 20}, []int{3, 4}, 20, false, 0, 0},
+		{"don't come lays odds and wins on 7", 6, bet{kind: DontCome, number: 4, amount: 10, odds: 20}, []int{3, 4}, 50, false, 0, 0},
+		{"don't come loses when its point is made", 6, bet{kind: DontCome, number: 4, amount: 10, odds: 20}, []int{2, 2}, 0, false, 0, 6},
+		{"pass wins on the come out 7", 0, bet{kind: PassLine, amount: 10}, []int{3, 4}, 20, false, 0, 0},
+		{"pass loses on the come out 12", 0, bet{kind: PassLine, amount: 10}, []int{6, 6}, 0, false, 0, 0},
+		{"pass follows the new point", 0, bet{kind: PassLine, amount: 10}, []int{2, 4}, 0, true, 0, 6},
+		{"pass point made with odds", 8, bet{kind: PassLine, amount: 10, odds: 10}, []int{5, 3}, 42, false, 0, 0},
+		{"pass loses on the seven out", 8, bet{kind: PassLine, amount: 10, odds: 10}, []int{5, 2}, 0, false, 0, 0},
+		{"don't pass wins on the come out 3", 0, bet{kind: DontPass, amount: 10}, []int{1, 2}, 20, false, 0, 0},
+		{"don't pass pushes on the come out 12", 0, bet{kind: DontPass, amount: 10}, []int{6, 6}, 0, true, 0, 0},
+		{"place pays and stays up", 4, bet{kind: Place, number: 6, amount: 12}, []int{1, 5}, 14, true, 6, 4},
+		{"place is off on the come out", 0, bet{kind: Place, number: 6, amount: 12}, []int{1, 5}, 0, true, 6, 6},
+		{"place loses on the seven out", 4, bet{kind: Place, number: 6, amount: 12}, []int{1, 6}, 0, false, 0, 0},
+		{"hard 8 pays and stays up", 5, bet{kind: Hardway, number: 8, amount: 10}, []int{4, 4}, 90, true, 8, 5},
+		{"hard 8 loses to an easy 8", 5, bet{kind: Hardway, number: 8, amount: 10}, []int{5, 3}, 0, false, 0, 5},
+		{"field pays double on 2", 0, bet{kind: Field, amount: 10}, []int{1, 1}, 30, false, 0, 0},
+		{"field pays triple on 12", 0, bet{kind: Field, amount: 10}, []int{6, 6}, 40, false, 0, 0},
+		{"field pays even money on 9", 0, bet{kind: Field, amount: 10}, []int{4, 5}, 20, false, 0, 9},
+		{"field loses on 7", 5, bet{kind: Field, amount: 10}, []int{3, 4}, 0, false, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := test.bet
+			c := &craps{point: test.point, bets: []*bet{&b}}
+
+			returned, messages := c.resolve(test.faces)
+			if returned != test.returned {
+				t.Errorf("returned %d chips, want %d", returned, test.returned)
+			}
+			if up := len(c.bets) == 1; up != test.up {
+				t.Fatalf("bet still up = %t, want %t (%v)", up, test.up, messages)
+			}
+			if test.up && c.bets[0].number != test.number {
+				t.Errorf("bet is on %d, want %d", c.bets[0].number, test.number)
+			}
+			if c.point != test.newPoint {
+				t.Errorf("point is %d, want %d", c.point, test.newPoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package craps
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"casino/games"
+	"casino/utils"
+)
+
+// historyLength is how many of the latest rolls are shown
+const historyLength = 12
+
+type craps struct {
+	saveManager  utils.SaveDataManager
+	dice         utils.Dice
+	oddsMultiple int
+
+	point     int // 0 on the come out roll
+	bets      []*bet
+	lastRoll  []int
+	history   [][]int // the latest rolls, oldest first
+	userChips int
+	messages  []string // what happened since the table was last shown
+
+	in  chan string
+	out chan string
+}
+
+func NewCraps(
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	c := &craps{
+		saveManager:  saveManager,
+		dice:         utils.NewDice(2, 6),
+		oddsMultiple: DefaultMaxOdds,
+		in:           in,
+		out:          out,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Option configures a craps game
+type Option func(*craps)
+
+// WithMaxOdds sets how many times the flat bet the player may take or lay in odds
+func WithMaxOdds(multiple int) Option {
+	return func(c *craps) { c.oddsMultiple = max(1, multiple) }
+}
+
+func (c *craps) Name() string {
+	return "Craps"
+}
+
+// Play runs rolls until the player chooses to leave the table. Bets stay on the
+// table from one roll to the next, so there's no asking to play again.
+func (c *craps) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := c.saveManager.Read().RemainingChips
+	c.messages = []string{utils.Dim("Type help to see how to bet")}
+
+	for {
+		rolled, err := c.playRound(ctx)
+		if rolled {
+			result.Rounds++
+		}
+
+		if err != nil || !rolled {
+			result.NetChips = c.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes bets until the dice are rolled, then settles the roll.
+// Returns whether the dice were rolled. If ctx is canceled the player leaves
+// the table, see leaveTable.
+func (c *craps) playRound(ctx context.Context) (bool, error) {
+	for {
+		c.userChips = c.saveManager.Read().RemainingChips
+		c.printTable()
+		c.out <- "Bet (e.g. pass 10, place 6 12, odds pass 20), take bets down, roll (r), leave or help"
+		line, err := utils.ReadLine(ctx, c.in)
+		if err != nil {
+			return c.leaveTable(), err
+		}
+
+		c.messages = nil
+		words := strings.Fields(strings.ToLower(line))
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "roll", "r":
+			c.roll()
+			return true, nil
+		case "leave":
+			if c.hasContract() {
+				c.messages = append(c.messages, utils.Yellow("Your pass and come bets have to be decided before you leave"))
+				continue
+			}
+			c.takeDownAll()
+			return false, nil
+		case "help":
+			c.messages = append(c.messages, BetHelp...)
+		case "down":
+			c.takeDown(words[1:])
+		case "odds":
+			c.addOdds(words[1:])
+		default:
+			c.addBet(words)
+		}
+	}
+}
+
+// parseAmount reads the chips at the end of a bet, checking the player has them
+func (c *craps) parseAmount(words []string) ([]string, int, error) {
+	if len(words) < 2 {
+		return nil, 0, fmt.Errorf("say how much to bet, e.g. %s 10", strings.Join(words, " "))
+	}
+
+	amount, err := strconv.Atoi(words[len(words)-1])
+	switch {
+	case err != nil || amount < 1:
+		return nil, 0, fmt.Errorf("%q isn't a bet, bets are a whole number of chips", words[len(words)-1])
+	case amount > c.userChips:
+		return nil, 0, fmt.Errorf("you only have %d chips", c.userChips)
+	}
+
+	return words[:len(words)-1], amount, nil
+}
+
+// addBet puts chips on the table, adding to a bet already in the same spot
+func (c *craps) addBet(words []string) {
+	words, amount, err := c.parseAmount(words)
+	if err == nil {
+		var kind BetKind
+		var number int
+		kind, number, err = c.parseBet(words)
+		if err == nil {
+			b := c.findBet(kind, number)
+			if b == nil {
+				b = &bet{kind: kind, number: number}
+				c.bets = append(c.bets, b)
+			}
+			b.amount += amount
+			c.take(amount)
+			c.messages = append(c.messages, utils.Green("%d on %s", b.amount, b.name()))
+			return
+		}
+	}
+
+	c.messages = append(c.messages, utils.Red("%s", err.Error()))
+}
+
+// addOdds puts odds behind a line or come bet, up to the table's limit
+func (c *craps) addOdds(words []string) {
+	words, amount, err := c.parseAmount(words)
+	if err != nil {
+		c.messages = append(c.messages, utils.Red("%s", err.Error()))
+		return
+	}
+	target, err := c.parseOddsTarget(words)
+	if err != nil {
+		c.messages = append(c.messages, utils.Red("%s", err.Error()))
+		return
+	}
+
+	if limit := c.maxOdds(target); target.odds+amount > limit {
+		c.messages = append(c.messages, utils.Yellow("You can have up to %d in odds behind your %s bet", limit, target.name()))
+		return
+	}
+
+	target.odds += amount
+	c.take(amount)
+	c.messages = append(c.messages, utils.Green("%d in odds behind %s", target.odds, target.name()))
+}
+
+// takeDown returns a bet, or the odds behind one, to the player
+func (c *craps) takeDown(words []string) {
+	if len(words) == 0 {
+		c.messages = append(c.messages, utils.Red("Say which bet to take down, e.g. down place 6"))
+		return
+	}
+
+	if words[0] == "odds" {
+		target, err := c.parseOddsTarget(words[1:])
+		switch {
+		case err != nil:
+			c.messages = append(c.messages, utils.Red("%s", err.Error()))
+		case target.odds == 0:
+			c.messages = append(c.messages, utils.Yellow("There are no odds behind your %s bet", target.name()))
+		default:
+			c.give(target.odds)
+			c.messages = append(c.messages, utils.Green("Took down %d in odds behind %s", target.odds, target.name()))
+			target.odds = 0
+		}
+		return
+	}
+
+	kind, ok := betWords[words[0]]
+	number := 0
+	if len(words) > 1 {
+		number, _ = strconv.Atoi(words[1])
+	}
+	var b *bet
+	if ok {
+		b = c.findBet(kind, number)
+	}
+
+	switch {
+	case b == nil:
+		c.messages = append(c.messages, utils.Yellow("You don't have a %s bet", strings.Join(words, " ")))
+	case b.contract(c.point):
+		c.messages = append(c.messages, utils.Yellow("%s stays up until it wins or loses", b.name()))
+	default:
+		c.give(b.amount + b.odds)
+		c.bets = slices.DeleteFunc(c.bets, func(other *bet) bool { return other == b })
+		c.messages = append(c.messages, utils.Green("Took down %s (%d chips)", b.name(), b.amount+b.odds))
+	}
+}
+
+// roll throws the dice and settles the bets it decides. What happened is shown
+// the next time the table is printed.
+func (c *craps) roll() {
+	wasPoint := c.point
+	faces := c.dice.Roll()
+	c.lastRoll = faces
+	c.history = append(c.history, faces)
+	c.history = c.history[max(0, len(c.history)-historyLength):]
+
+	returned, results := c.resolve(faces)
+	c.give(returned)
+
+	c.messages = append([]string{utils.Bold("%s %s", renderDice(faces), callRoll(faces, wasPoint))}, results...)
+}
+
+// callRoll is what the stickman calls out for a roll
+func callRoll(faces []int, point int) string {
+	total := faces[0] + faces[1]
+	hard := ""
+	if faces[0] == faces[1] && slices.Contains(Points, total) {
+		hard = ", the hard way"
+	}
+
+	switch {
+	case point == 0 && (total == 7 || total == 11):
+		return fmt.Sprintf("%d, winner on the come out", total)
+	case point == 0 && (total == 2 || total == 3 || total == 12):
+		return fmt.Sprintf("%d, craps", total)
+	case point == 0:
+		return fmt.Sprintf("%d%s, the point is %d", total, hard, total)
+	case total == point:
+		return fmt.Sprintf("%d%s, the point is made", total, hard)
+	case total == 7:
+		return "7, seven out"
+	default:
+		return fmt.Sprintf("%d%s", total, hard)
+	}
+}
+
+// hasContract reports whether any of the player's bets can't be taken down
+func (c *craps) hasContract() bool {
+	return slices.ContainsFunc(c.bets, func(b *bet) bool { return b.contract(c.point) })
+}
+
+// takeDownAll returns every bet on the table to the player
+func (c *craps) takeDownAll() {
+	for _, b := range c.bets {
+		c.give(b.amount + b.odds)
+	}
+	c.bets = nil
+}
+
+// leaveTable settles up when the player stops answering. Every bet that can
+// come down is returned, and the dice keep rolling until the pass and come bets
+// left are decided. Returns whether the dice were rolled.
+func (c *craps) leaveTable() bool {
+	for _, b := range c.bets {
+		c.give(b.odds)
+		b.odds = 0
+		if !b.contract(c.point) {
+			c.give(b.amount)
+			b.amount = 0
+		}
+	}
+	c.bets = slices.DeleteFunc(c.bets, func(b *bet) bool { return b.amount == 0 })
+
+	rolled := false
+	for c.hasContract() {
+		c.roll()
+		rolled = true
+		for _, message := range c.messages {
+			c.out <- message
+		}
+	}
+	c.takeDownAll()
+
+	return rolled
+}
+
+// take moves chips from the player's bankroll onto the table
+func (c *craps) take(chips int) {
+	save := c.saveManager.Read()
+	save.RemainingChips -= chips
+	c.saveManager.Save(save)
+	c.userChips = save.RemainingChips
+}
+
+// give moves chips from the table back to the player's bankroll
+func (c *craps) give(chips int) {
+	if chips == 0 {
+		return
+	}
+
+	save := c.saveManager.Read()
+	save.RemainingChips += chips
+	c.saveManager.Save(save)
+	c.userChips = save.RemainingChips
+}
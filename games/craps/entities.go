@@ -0,0 +1,108 @@
+package craps
+
+import "fmt"
+
+// DefaultMaxOdds is how many times the flat bet the player may take or lay in
+// odds when no other limit is set
+const DefaultMaxOdds = 3
+
+// Points are the numbers that can become the point, and the numbers come, place
+// and hardway bets sit on
+var Points = []int{4, 5, 6, 8, 9, 10}
+
+// BetKind is where on the table a bet is placed
+type BetKind int
+
+const (
+	PassLine BetKind = iota
+	DontPass
+	Come
+	DontCome
+	Place
+	Field
+	Hardway
+)
+
+var BetKindToString = map[BetKind]string{
+	PassLine: "Pass line",
+	DontPass: "Don't pass",
+	Come:     "Come",
+	DontCome: "Don't come",
+	Place:    "Place",
+	Field:    "Field",
+	Hardway:  "Hard",
+}
+
+// payout is how much a winning bet pays, e.g. 6 to 5
+type payout struct {
+	win   int
+	stake int
+}
+
+// winnings is how much a wager wins, not including the wager. Fractions of a
+// chip are rounded down.
+func (p payout) winnings(wager int) int {
+	return wager * p.win / p.stake
+}
+
+func (p payout) String() string {
+	return fmt.Sprintf("%d to %d", p.win, p.stake)
+}
+
+var (
+	evenMoney = payout{win: 1, stake: 1}
+
+	// trueOdds pay odds behind a pass or come bet at the real chance of the
+	// point being made
+	trueOdds = map[int]payout{
+		4: {2, 1}, 10: {2, 1},
+		5: {3, 2}, 9: {3, 2},
+		6: {6, 5}, 8: {6, 5},
+	}
+	// layOdds pay odds behind a don't pass or don't come bet
+	layOdds = map[int]payout{
+		4: {1, 2}, 10: {1, 2},
+		5: {2, 3}, 9: {2, 3},
+		6: {5, 6}, 8: {5, 6},
+	}
+	placePays = map[int]payout{
+		4: {9, 5}, 10: {9, 5},
+		5: {7, 5}, 9: {7, 5},
+		6: {7, 6}, 8: {7, 6},
+	}
+	hardwayPays = map[int]payout{
+		4: {7, 1}, 10: {7, 1},
+		6: {9, 1}, 8: {9, 1},
+	}
+	// fieldPays are the field numbers that pay more than even money
+	fieldPays = map[int]payout{
+		2:  {2, 1},
+		12: {3, 1},
+	}
+	fieldNumbers = []int{2, 3, 4, 9, 10, 11, 12}
+)
+
+// bet is chips on the table
+type bet struct {
+	kind BetKind
+	// number is the point a come or don't come bet moved to, or the number a
+	// place or hardway bet is on. It's 0 for a come bet still in the come box,
+	// and unused for line bets and the field, which follow the table.
+	number int
+	amount int
+	odds   int
+}
+
+// contract reports whether the bet has to stay up until it's decided. A pass or
+// come bet can't be taken down once it has a point, since the come out roll
+// that favored the player has already been played.
+func (b *bet) contract(point int) bool {
+	switch b.kind {
+	case PassLine:
+		return point != 0
+	case Come:
+		return true
+	default:
+		return false
+	}
+}
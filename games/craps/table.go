@@ -0,0 +1,122 @@
+package craps
+
+import (
+	"fmt"
+	"strings"
+
+	"casino/utils"
+)
+
+const (
+	// labelWidth is how wide the names of the rows of number boxes are drawn
+	labelWidth = 11
+	// boxWidth is how wide each number box is drawn, not counting borders
+	boxWidth = 7
+)
+
+// diceFaces are the faces of a six sided die, from one to six
+var diceFaces = []string{"⚀", "⚁", "⚂", "⚃", "⚄", "⚅"}
+
+// renderDice draws a roll as dice faces
+func renderDice(faces []int) string {
+	var dice []string
+	for _, face := range faces {
+		dice = append(dice, diceFaces[face-1])
+	}
+
+	return strings.Join(dice, " ")
+}
+
+// printTable shows where the player's chips sit on the layout, the latest rolls
+// and what happened since the table was last shown
+func (c *craps) printTable() {
+	utils.Clear(c.out)
+
+	onTable := 0
+	for _, b := range c.bets {
+		onTable += b.amount + b.odds
+	}
+	c.out <- utils.Dim("Your chips: %d (%d on the table)", c.userChips, onTable)
+	c.out <- utils.Dim("Odds: up to %dx", c.oddsMultiple)
+	if len(c.history) > 0 {
+		var rolls []string
+		for _, faces := range c.history {
+			rolls = append(rolls, fmt.Sprint(faces[0]+faces[1]))
+		}
+		c.out <- utils.Dim("Last rolls: %s", strings.Join(rolls, " "))
+	}
+	if c.point == 0 {
+		c.out <- utils.Bold("Point: OFF") + utils.Dim(" (come out roll)")
+	} else {
+		c.out <- utils.Bold("Point: ON %d", c.point)
+	}
+	c.out <- utils.Divider()
+
+	for _, line := range c.renderBoxes() {
+		c.out <- line
+	}
+	c.out <- c.renderSpot("Come", Come, 0) + "   " + c.renderSpot("Don't come", DontCome, 0)
+	c.out <- c.renderSpot("Field", Field, 0) + utils.Dim("   2 and 12 pay double and triple")
+	c.out <- c.renderSpot("Pass line", PassLine, 0) + "   " + c.renderSpot("Don't pass", DontPass, 0)
+	c.out <- utils.Divider()
+
+	for _, message := range c.messages {
+		c.out <- message
+	}
+}
+
+// renderBoxes draws the numbers 4 to 10 with the place, come, don't come and
+// hardway bets on each. Come bets show their odds after a plus.
+func (c *craps) renderBoxes() []string {
+	border := func(left, middle, right string) string {
+		return strings.Repeat(" ", labelWidth) + left +
+			strings.Repeat(strings.Repeat("─", boxWidth)+middle, len(Points)-1) +
+			strings.Repeat("─", boxWidth) + right
+	}
+	row := func(label string, box func(number int) string) string {
+		var boxes []string
+		for _, number := range Points {
+			boxes = append(boxes, box(number))
+		}
+		return utils.Dim("%s", utils.PadRight(label, labelWidth)) + "│" + strings.Join(boxes, "│") + "│"
+	}
+
+	lines := []string{border("┌", "┬", "┐")}
+	lines = append(lines, row("", func(number int) string {
+		label := utils.PadCenter(fmt.Sprint(number), boxWidth)
+		if number == c.point {
+			return utils.Yellow(utils.Bold("%s", utils.PadCenter(fmt.Sprintf("●%d●", number), boxWidth)))
+		}
+		return utils.Bold("%s", label)
+	}))
+	lines = append(lines, border("├", "┼", "┤"))
+	for _, kind := range []BetKind{Place, Come, DontCome, Hardway} {
+		lines = append(lines, row(BetKindToString[kind], func(number int) string {
+			return chips(c.findBet(kind, number), boxWidth)
+		}))
+	}
+	lines = append(lines, border("└", "┴", "┘"))
+
+	return lines
+}
+
+// renderSpot draws one of the spots below the number boxes with the player's
+// bet on it
+func (c *craps) renderSpot(label string, kind BetKind, number int) string {
+	return utils.Dim("%s", utils.PadRight(label, labelWidth)) + chips(c.findBet(kind, number), boxWidth)
+}
+
+// chips draws a bet centred in width, e.g. "10+20" for a bet with odds behind
+// it, or blank if there's no bet
+func chips(b *bet, width int) string {
+	if b == nil {
+		return strings.Repeat(" ", width)
+	}
+
+	amount := fmt.Sprint(b.amount)
+	if b.odds > 0 {
+		amount += fmt.Sprintf("+%d", b.odds)
+	}
+
+	return utils.Cyan("%s", utils.PadCenter(amount, width))
+}
@@ -1,6 +1,19 @@
 package games
 
+import "context"
+
+// Result is what happened while the player sat at a game
+type Result struct {
+	Rounds int
+	// NetChips is how many chips the player won, negative if they lost chips
+	NetChips int
+}
+
 type Game interface {
 	Name() string
-	Play()
+	// Play runs the game until the player leaves the table or ctx is canceled.
+	// A round that is in progress when ctx is canceled is finished with the
+	// passive choice at every decision (stand, decline, fold) and settled before
+	// Play returns ctx.Err().
+	Play(ctx context.Context) (Result, error)
 }
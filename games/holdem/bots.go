@@ -0,0 +1,126 @@
+package holdem
+
+import (
+	"math/rand"
+
+	"casino/entities"
+	"casino/evaluator"
+	"casino/utils"
+)
+
+// equityTrials is how many hands a bot deals out to estimate its chances
+const equityTrials = 400
+
+var botNames = []string{"Ada", "Bea", "Cal", "Dot", "Eli", "Fay", "Gus", "Hal", "Ivy", "Jo"}
+
+// bot decides how a computer player plays its hands
+type bot struct {
+	// tightness is how much more often than the pot odds demand a bot needs to
+	// win before it calls
+	tightness float64
+	// raiseAt is how much better than an average hand at the table a bot needs
+	// before it bets or raises, as a multiple of its fair share of the pot
+	raiseAt float64
+	// bluff is the chance a bot bets or raises with any hand
+	bluff float64
+}
+
+// botStyles are the kinds of players that sit at the table
+var botStyles = []bot{
+	{tightness: 0.1, raiseAt: 1.6, bluff: 0.02},    // rock: few hands, played hard
+	{tightness: 0.03, raiseAt: 1.35, bluff: 0.06},  // regular
+	{tightness: -0.03, raiseAt: 1.15, bluff: 0.15}, // maniac: bets anything
+	{tightness: -0.08, raiseAt: 1.7, bluff: 0.03},  // calling station: calls anything
+}
+
+func newBot() *bot {
+	style := botStyles[rand.Intn(len(botStyles))]
+	return &style
+}
+
+// decide picks an action for a bot based on how likely it is to win the hand
+func (b *bot) decide(h *holdem, s *seat) action {
+	opponents := -1
+	for _, other := range h.seats {
+		if other.contending() {
+			opponents++
+		}
+	}
+	chance := equity(s.hole, h.board, opponents)
+	share := chance * float64(opponents+1)
+
+	toCall := h.currentBet - s.bet
+	potSize := h.potSize()
+	bluffing := rand.Float64() < b.bluff
+
+	if h.canRaise(s) && (share >= b.raiseAt || bluffing) {
+		// Bet somewhere between half the pot and the whole pot
+		size := int(float64(potSize+toCall) * (0.5 + rand.Float64()/2))
+		to := h.currentBet + max(h.minRaise, size)
+		if to >= s.bet+s.stack {
+			return action{kind: allIn}
+		}
+		return action{kind: raise, to: to}
+	}
+
+	if toCall == 0 {
+		return action{kind: check}
+	}
+
+	potOdds := float64(toCall) / float64(potSize+toCall)
+	if chance >= potOdds+b.tightness {
+		return action{kind: call}
+	}
+
+	return action{kind: fold}
+}
+
+// equity estimates how often a hand wins at showdown against opponents holding
+// random cards, by dealing out the rest of the hand many times. A tie counts as
+// a share of a win.
+func equity(hole, board []entities.Card, opponents int) float64 {
+	known := make(map[string]bool, len(hole)+len(board))
+	for _, card := range append(append([]entities.Card{}, hole...), board...) {
+		known[card.Code] = true
+	}
+	var deck []entities.Card
+	for _, card := range utils.GenerateStandardDeck().DrawPile {
+		if !known[card.Code] {
+			deck = append(deck, card)
+		}
+	}
+
+	missing := 5 - len(board)
+	needed := opponents*2 + missing
+	fullBoard := make([]entities.Card, 0, 5)
+	hand := make([]entities.Card, 0, 7)
+
+	wins := 0.0
+	for range equityTrials {
+		// Only the cards that are needed get shuffled to the front
+		for i := range needed {
+			j := i + rand.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		fullBoard = append(append(fullBoard[:0], board...), deck[opponents*2:needed]...)
+
+		mine := evaluator.Evaluate(append(append(hand[:0], hole...), fullBoard...))
+		ties := 0
+		lost := false
+		for o := range opponents {
+			theirs := evaluator.Evaluate(append(append(hand[:0], deck[o*2:o*2+2]...), fullBoard...))
+			if theirs > mine {
+				lost = true
+				break
+			}
+			if theirs == mine {
+				ties++
+			}
+		}
+		if !lost {
+			wins += 1 / float64(ties+1)
+		}
+	}
+
+	return wins / equityTrials
+}
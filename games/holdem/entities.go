@@ -0,0 +1,101 @@
+package holdem
+
+import "casino/entities"
+
+const (
+	SmallBlind = 5
+	BigBlind   = 10
+
+	// MinBuyIn and MaxBuyIn are how many chips the player can sit down with.
+	// Bots always sit down with MaxBuyIn.
+	MinBuyIn = 20 * BigBlind
+	MaxBuyIn = 100 * BigBlind
+
+	MinSeats = 2
+	MaxSeats = 8
+)
+
+// Street is a betting round
+type Street int
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+)
+
+var StreetToString = map[Street]string{
+	Preflop: "Preflop",
+	Flop:    "Flop",
+	Turn:    "Turn",
+	River:   "River",
+}
+
+// StreetToBoardCards is how many board cards are dealt at the start of a street
+var StreetToBoardCards = map[Street]int{
+	Preflop: 0,
+	Flop:    3,
+	Turn:    1,
+	River:   1,
+}
+
+type actionKind int
+
+const (
+	fold actionKind = iota
+	check
+	call
+	raise // a bet is a raise from nothing
+	allIn
+)
+
+// action is what a player does when it's their turn to act
+type action struct {
+	kind actionKind
+	to   int // for raises, the total the player's bet is raised to
+}
+
+// seat is a player at the table, either the user or a bot
+type seat struct {
+	name  string
+	bot   *bot // nil for the user
+	stack int
+	hole  []entities.Card
+
+	bet       int // chips put in during the current betting round
+	committed int // chips put in during the whole hand, including bet
+	folded    bool
+	allIn     bool
+	acted     bool // acted since the last full bet or raise
+}
+
+// contending reports whether the seat can still win the pot
+func (s *seat) contending() bool {
+	return !s.folded
+}
+
+// canAct reports whether the seat still has decisions to make this hand
+func (s *seat) canAct() bool {
+	return !s.folded && !s.allIn
+}
+
+// put moves chips from the seat's stack into its bet, going all in if it doesn't
+// have enough. Returns how many chips were put in.
+func (s *seat) put(chips int) int {
+	chips = min(chips, s.stack)
+	s.stack -= chips
+	s.bet += chips
+	s.committed += chips
+	if s.stack == 0 {
+		s.allIn = true
+	}
+
+	return chips
+}
+
+// pot is the main pot or a side pot, and the seats that can win it
+type pot struct {
+	amount   int
+	eligible []int
+}
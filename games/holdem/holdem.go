@@ -0,0 +1,682 @@
+package holdem
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"casino/entities"
+	"casino/evaluator"
+	"casino/games"
+	"casino/utils"
+)
+
+// logLines is how much of the hand's history is shown under the table
+const logLines = 12
+
+type holdem struct {
+	dealer      utils.Dealer
+	saveManager utils.SaveDataManager
+
+	seats      []*seat // seats[0] is the user
+	button     int
+	bigBlind   int
+	street     Street
+	board      []entities.Card
+	currentBet int // the bet everyone still in has to match this betting round
+	minRaise   int // the smallest raise allowed, the size of the last full bet or raise
+	showdown   bool
+	log        []string // what has happened this hand
+
+	in  chan string
+	out chan string
+}
+
+func NewHoldem(
+	dealer utils.Dealer,
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+) games.Game {
+	return &holdem{
+		dealer:      dealer,
+		saveManager: saveManager,
+		in:          in,
+		out:         out,
+	}
+}
+
+func (h *holdem) Name() string {
+	return "Texas Hold'em"
+}
+
+// Play runs hands until the player chooses to leave the table. Every hand
+// returns here before the next one starts.
+func (h *holdem) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := h.saveManager.Read().RemainingChips
+
+	// Everyone gets up when the player leaves, so it's a new table next time
+	h.seats = nil
+
+	for {
+		played, err := h.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = h.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = h.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound seats the player if they aren't yet, plays one hand and saves how
+// many chips they won or lost. Returns whether a hand was dealt. Once the cards
+// are out the hand is always finished, even if ctx is canceled part way through.
+func (h *holdem) playRound(ctx context.Context) (bool, error) {
+	utils.Clear(h.out)
+	utils.PrintBanner(h.Name(), h.out)
+
+	if h.seats == nil {
+		if err := h.chooseSeats(ctx); err != nil {
+			return false, err
+		}
+	}
+	user := h.seats[0]
+	if user.stack == 0 {
+		bought, err := h.buyIn(ctx)
+		if err != nil || !bought {
+			return false, err
+		}
+	}
+
+	h.log = nil
+	h.replaceBrokeBots()
+
+	startingStack := user.stack
+	h.playHand(ctx)
+
+	// The player's stack is part of their chips, so only what changed is saved
+	save := h.saveManager.Read()
+	save.RemainingChips += user.stack - startingStack
+	h.saveManager.Save(save)
+
+	return true, nil
+}
+
+// chooseSeats asks the player how many players they want at the table and
+// fills the other seats with bots
+func (h *holdem) chooseSeats(ctx context.Context) error {
+	var choices []string
+	for n := MinSeats; n <= MaxSeats; n++ {
+		choices = append(choices, fmt.Sprint(n))
+	}
+	choice, err := utils.GetInput(
+		ctx,
+		h.in,
+		h.out,
+		choices,
+		fmt.Sprintf("How many players at the table, including you? (%d-%d)", MinSeats, MaxSeats),
+	)
+	if err != nil {
+		return err
+	}
+	players, _ := strconv.Atoi(choice)
+
+	names := slices.Clone(botNames)
+	utils.Shuffle(names)
+	h.seats = []*seat{{name: "You"}}
+	for _, name := range names[:players-1] {
+		h.seats = append(h.seats, &seat{name: name, bot: newBot(), stack: MaxBuyIn})
+	}
+	h.button = rand.Intn(players)
+
+	return nil
+}
+
+// buyIn asks the player how many of their chips to bring to the table. Returns
+// false if they don't have enough to play.
+func (h *holdem) buyIn(ctx context.Context) (bool, error) {
+	chips := h.saveManager.Read().RemainingChips
+	if chips < BigBlind {
+		h.out <- utils.Red("You need at least %d chips to sit down", BigBlind)
+		return false, nil
+	}
+
+	minBuyIn := min(MinBuyIn, chips)
+	maxBuyIn := min(MaxBuyIn, chips)
+	h.out <- utils.Dim("You have %d chips. Blinds are %d/%d.", chips, SmallBlind, BigBlind)
+	buyIn, err := utils.GetBet(
+		ctx,
+		h.in,
+		h.out,
+		fmt.Sprintf("How much do you want to buy in for? (%d-%d)", minBuyIn, maxBuyIn),
+		minBuyIn,
+		maxBuyIn,
+	)
+	if err != nil {
+		return false, err
+	}
+	h.seats[0].stack = buyIn
+
+	return true, nil
+}
+
+// replaceBrokeBots gives the seat of any bot that has lost all its chips to a
+// new bot
+func (h *holdem) replaceBrokeBots() {
+	taken := map[string]bool{}
+	for _, s := range h.seats {
+		taken[s.name] = true
+	}
+
+	for _, s := range h.seats {
+		if s.bot == nil || s.stack > 0 {
+			continue
+		}
+
+		names := slices.Clone(botNames)
+		utils.Shuffle(names)
+		i := slices.IndexFunc(names, func(name string) bool { return !taken[name] })
+		taken[names[i]] = true
+
+		h.log = append(h.log, utils.Dim("%s is out of chips, %s sits down", s.name, names[i]))
+		*s = seat{name: names[i], bot: newBot(), stack: MaxBuyIn}
+	}
+}
+
+// playHand deals a hand and plays it out to the end. If ctx is canceled the
+// player checks or folds whenever it's their turn.
+func (h *holdem) playHand(ctx context.Context) {
+	h.button = h.nextSeat(h.button)
+	h.dealer.Shuffle()
+	h.board = nil
+	h.showdown = false
+	for _, s := range h.seats {
+		*s = seat{name: s.name, bot: s.bot, stack: s.stack}
+	}
+
+	if err := h.deal(); err != nil {
+		h.misdeal(err)
+		return
+	}
+
+	for street := Preflop; street <= River; street++ {
+		if err := h.startStreet(street); err != nil {
+			h.misdeal(err)
+			return
+		}
+		h.bettingRound(ctx)
+		if h.contenders() == 1 {
+			break
+		}
+	}
+
+	h.settle()
+	h.endHand()
+}
+
+// deal gives every seat two hole cards, one at a time starting left of the
+// button. Only the player's cards are face up.
+func (h *holdem) deal() error {
+	for range 2 {
+		for n := range len(h.seats) {
+			s := h.seats[(h.button+1+n)%len(h.seats)]
+			card, err := h.dealer.Draw()
+			if err != nil {
+				return err
+			}
+			card.Hidden = s.bot != nil
+			s.hole = append(s.hole, card)
+		}
+	}
+
+	return nil
+}
+
+// startStreet gets ready for a betting round: the blinds go in before the flop,
+// and the board cards are dealt after it
+func (h *holdem) startStreet(street Street) error {
+	h.street = street
+	h.currentBet = 0
+	h.minRaise = BigBlind
+	for _, s := range h.seats {
+		s.bet = 0
+		s.acted = false
+	}
+
+	if street == Preflop {
+		h.postBlinds()
+		return nil
+	}
+
+	// The top card is burned before each street
+	burn, err := h.dealer.Draw()
+	if err != nil {
+		return err
+	}
+	h.dealer.Discard(burn)
+
+	cards, err := h.dealer.DrawCards(entities.DrawOpts{Count: StreetToBoardCards[street]})
+	h.board = append(h.board, cards...)
+	if err != nil {
+		return err
+	}
+	h.log = append(h.log, utils.Cyan("%s:%s", StreetToString[street], entities.Hand{Cards: h.board}))
+
+	return nil
+}
+
+// postBlinds takes the small and big blinds from the two seats left of the
+// button. Heads up, the button posts the small blind.
+func (h *holdem) postBlinds() {
+	small := h.nextSeat(h.button)
+	if len(h.seats) == 2 {
+		small = h.button
+	}
+	h.bigBlind = h.nextSeat(small)
+
+	posted := h.seats[small].put(SmallBlind)
+	h.logAction(h.seats[small], fmt.Sprintf("post the small blind (%d)", posted), fmt.Sprintf("posts the small blind (%d)", posted))
+	posted = h.seats[h.bigBlind].put(BigBlind)
+	h.logAction(h.seats[h.bigBlind], fmt.Sprintf("post the big blind (%d)", posted), fmt.Sprintf("posts the big blind (%d)", posted))
+
+	h.currentBet = max(h.seats[small].bet, h.seats[h.bigBlind].bet)
+}
+
+// bettingRound goes around the table until everyone still in the hand has
+// matched the bet, gone all in or folded
+func (h *holdem) bettingRound(ctx context.Context) {
+	i := h.nextSeat(h.button)
+	if h.street == Preflop {
+		i = h.nextSeat(h.bigBlind)
+	}
+
+	for h.contenders() > 1 {
+		next, ok := h.nextToAct(i)
+		if !ok {
+			return
+		}
+
+		s := h.seats[next]
+		var a action
+		if s.bot != nil {
+			a = s.bot.decide(h, s)
+		} else {
+			a = h.userAction(ctx)
+		}
+		h.apply(s, a)
+		i = h.nextSeat(next)
+	}
+}
+
+// nextToAct finds the first seat from i onwards, going round the table, that
+// has to act before the betting round can end
+func (h *holdem) nextToAct(i int) (int, bool) {
+	for range len(h.seats) {
+		if h.needsToAct(h.seats[i]) {
+			return i, true
+		}
+		i = h.nextSeat(i)
+	}
+
+	return 0, false
+}
+
+func (h *holdem) needsToAct(s *seat) bool {
+	if !s.canAct() {
+		return false
+	}
+	if s.bet < h.currentBet {
+		return true
+	}
+
+	// Once everyone else is all in there's nobody left to bet against
+	return !s.acted && h.countCanAct() > 1
+}
+
+// canRaise reports whether a seat is allowed to bet or raise. Going all in for
+// less than a full raise doesn't let players who already acted raise again.
+func (h *holdem) canRaise(s *seat) bool {
+	return !s.acted && h.countCanAct() > 1 && s.stack > h.currentBet-s.bet
+}
+
+// apply carries out a seat's action
+func (h *holdem) apply(s *seat, a action) {
+	s.acted = true
+	if a.kind == allIn {
+		a = action{kind: raise, to: s.bet + s.stack}
+	}
+	if a.kind == raise && min(a.to, s.bet+s.stack) <= h.currentBet {
+		a.kind = call
+	}
+
+	var you, them string
+	switch a.kind {
+	case fold:
+		s.folded = true
+		you, them = "fold", "folds"
+	case check:
+		you, them = "check", "checks"
+	case call:
+		called := s.put(h.currentBet - s.bet)
+		you, them = fmt.Sprintf("call %d", called), fmt.Sprintf("calls %d", called)
+	case raise:
+		to := min(a.to, s.bet+s.stack)
+		if h.currentBet == 0 {
+			you, them = fmt.Sprintf("bet %d", to), fmt.Sprintf("bets %d", to)
+		} else {
+			you, them = fmt.Sprintf("raise to %d", to), fmt.Sprintf("raises to %d", to)
+		}
+
+		// Only a full raise makes everyone else act again
+		if raisedBy := to - h.currentBet; raisedBy >= h.minRaise {
+			h.minRaise = raisedBy
+			for _, other := range h.seats {
+				if other != s {
+					other.acted = false
+				}
+			}
+		}
+		s.put(to - s.bet)
+		h.currentBet = to
+	}
+
+	if s.allIn {
+		you += ", all in"
+		them += ", all in"
+	}
+	h.logAction(s, you, them)
+}
+
+// userAction asks the player what they want to do. If they stop answering they
+// check, or fold if there's a bet to them.
+func (h *holdem) userAction(ctx context.Context) action {
+	s := h.seats[0]
+	toCall := h.currentBet - s.bet
+	passive := action{kind: check}
+	if toCall > 0 {
+		passive = action{kind: fold}
+	}
+
+	var commands, options []string
+	if toCall == 0 {
+		commands = append(commands, "check", "k")
+		options = append(options, "check (k)")
+	} else {
+		commands = append(commands, "fold", "f", "call", "c")
+		options = append(options, "fold (f)", fmt.Sprintf("call %d (c)", min(toCall, s.stack)))
+	}
+
+	verb := "raise"
+	if h.currentBet == 0 {
+		verb = "bet"
+	}
+	minTo := h.currentBet + h.minRaise
+	allInTo := s.bet + s.stack
+	if h.canRaise(s) {
+		if allInTo > minTo {
+			commands = append(commands, verb, verb[:1])
+			options = append(options, fmt.Sprintf("%s (%s)", verb, verb[:1]))
+		}
+		commands = append(commands, "all in", "a")
+		options = append(options, fmt.Sprintf("all in for %d (a)", s.stack))
+	}
+
+	h.printUpdate()
+	prompt := strings.Join(options[:len(options)-1], ", ") + " or " + options[len(options)-1] + "?"
+	choice, err := utils.GetInput(ctx, h.in, h.out, commands, strings.ToUpper(prompt[:1])+prompt[1:])
+	if err != nil {
+		return passive
+	}
+
+	switch choice {
+	case "fold", "f":
+		return action{kind: fold}
+	case "check", "k":
+		return action{kind: check}
+	case "call", "c":
+		return action{kind: call}
+	case "all in", "a":
+		return action{kind: allIn}
+	}
+
+	to, err := utils.GetBet(
+		ctx,
+		h.in,
+		h.out,
+		fmt.Sprintf("%s to how much? (%d-%d)", strings.ToUpper(verb[:1])+verb[1:], minTo, allInTo),
+		minTo,
+		allInTo,
+	)
+	if err != nil {
+		return passive
+	}
+
+	return action{kind: raise, to: to}
+}
+
+// settle gives back any bet nobody called and pays out the main pot and side
+// pots. If more than one player is left the hands are shown down.
+func (h *holdem) settle() {
+	if i, chips := returnUncalled(h.seats); i >= 0 {
+		h.logAction(h.seats[i], fmt.Sprintf("get %d back", chips), fmt.Sprintf("gets %d back", chips))
+	}
+
+	pots := buildPots(h.seats)
+	h.showdown = h.contenders() > 1
+	ranks := make(map[int]evaluator.Rank, len(h.seats))
+	for i, s := range h.seats {
+		if !h.showdown || !s.contending() {
+			continue
+		}
+		for c := range s.hole {
+			s.hole[c].Hidden = false
+		}
+		ranks[i] = evaluator.Evaluate(append(slices.Clone(s.hole), h.board...))
+	}
+	h.printUpdate()
+
+	for n, p := range pots {
+		best := slices.MaxFunc(p.eligible, func(a, b int) int { return int(ranks[a]) - int(ranks[b]) })
+		winners := slices.DeleteFunc(slices.Clone(p.eligible), func(i int) bool { return ranks[i] != ranks[best] })
+
+		// Odd chips go to the winners closest to the left of the button
+		slices.SortFunc(winners, func(a, b int) int { return h.seatsFromButton(a) - h.seatsFromButton(b) })
+		share, oddChips := p.amount/len(winners), p.amount%len(winners)
+
+		potName := "the pot"
+		switch {
+		case len(pots) > 1 && n == 0:
+			potName = "the main pot"
+		case len(pots) > 1:
+			potName = fmt.Sprintf("side pot %d", n)
+		}
+
+		for w, i := range winners {
+			won := share
+			if w < oddChips {
+				won++
+			}
+			h.seats[i].stack += won
+
+			message := fmt.Sprintf("%d from %s", won, potName)
+			if h.showdown {
+				message += fmt.Sprintf(" with %s", strings.ToLower(evaluator.Category(ranks[i].Category()).String()))
+			}
+			if h.seats[i].bot == nil {
+				h.out <- utils.Green(utils.Bold("You win %s", message))
+			} else {
+				h.out <- fmt.Sprintf("%s wins %s", h.seats[i].name, message)
+			}
+		}
+	}
+}
+
+// seatsFromButton counts how many seats to the left of the button seat i is
+func (h *holdem) seatsFromButton(i int) int {
+	return (i - h.button - 1 + len(h.seats)) % len(h.seats)
+}
+
+// misdeal calls off the hand when the dealer can't deal, giving everyone back
+// what they put in
+func (h *holdem) misdeal(err error) {
+	for _, s := range h.seats {
+		s.stack += s.committed
+		s.bet, s.committed = 0, 0
+	}
+
+	h.printUpdate()
+	h.out <- utils.Red("Misdeal: %s", err.Error())
+	h.out <- utils.Yellow("All bets returned")
+	h.endHand()
+}
+
+// endHand gathers up the cards once the hand is over
+func (h *holdem) endHand() {
+	for _, s := range h.seats {
+		h.dealer.Discard(s.hole...)
+		s.hole = nil
+	}
+	h.dealer.Discard(h.board...)
+	h.board = nil
+}
+
+// playAgain asks whether the player wants another hand. Returns false if they
+// want to leave the table.
+func (h *holdem) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		h.in,
+		h.out,
+		[]string{"yes", "y", "no", "n"},
+		"Play another hand? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// logAction records what a seat did, e.g. "Ada raises to 40" or "You raise to 40"
+func (h *holdem) logAction(s *seat, you, them string) {
+	if s.bot == nil {
+		h.log = append(h.log, "You "+you)
+		return
+	}
+	h.log = append(h.log, s.name+" "+them)
+}
+
+func (h *holdem) nextSeat(i int) int {
+	return (i + 1) % len(h.seats)
+}
+
+// contenders is how many seats can still win the pot
+func (h *holdem) contenders() int {
+	count := 0
+	for _, s := range h.seats {
+		if s.contending() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// countCanAct is how many seats still have decisions to make
+func (h *holdem) countCanAct() int {
+	count := 0
+	for _, s := range h.seats {
+		if s.canAct() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// potSize is every chip put in this hand, including bets not yet called
+func (h *holdem) potSize() int {
+	total := 0
+	for _, s := range h.seats {
+		total += s.committed
+	}
+
+	return total
+}
+
+func (h *holdem) printUpdate() {
+	utils.Clear(h.out)
+
+	h.out <- utils.Dim("Blinds: %d/%d", SmallBlind, BigBlind)
+	h.out <- utils.Dim("Pot: %d", h.potSize())
+	h.out <- utils.Divider()
+	for i, s := range h.seats {
+		h.out <- h.describeSeat(i, s)
+	}
+	h.out <- utils.Divider()
+
+	if len(h.board) > 0 {
+		h.out <- "Board:"
+		for _, line := range utils.RenderHand(entities.Hand{Cards: h.board}) {
+			h.out <- line
+		}
+	}
+	h.out <- "Your cards:"
+	for _, line := range utils.RenderHand(entities.Hand{Cards: h.seats[0].hole}) {
+		h.out <- line
+	}
+	h.out <- utils.Divider()
+
+	for _, line := range h.log[max(0, len(h.log)-logLines):] {
+		h.out <- line
+	}
+}
+
+// describeSeat is one line of the table: who's sitting there, their chips and
+// what they're doing this hand
+func (h *holdem) describeSeat(i int, s *seat) string {
+	marker := "  "
+	if i == h.button {
+		marker = "D "
+	}
+
+	line := fmt.Sprintf("%s%s %5d chips", marker, utils.PadRight(s.name, 4), s.stack)
+	if s.bot != nil && len(s.hole) > 0 {
+		line += fmt.Sprintf("  %s", entities.Hand{Cards: s.hole})
+	}
+	if s.bet > 0 {
+		line += fmt.Sprintf("  bet %d", s.bet)
+	}
+
+	switch {
+	case s.folded:
+		return utils.Dim("%s  folded", line)
+	case s.allIn:
+		line += "  all in"
+	}
+	if h.showdown && len(s.hole) > 0 {
+		rank := evaluator.Evaluate(append(slices.Clone(s.hole), h.board...))
+		line += fmt.Sprintf("  (%s)", evaluator.Category(rank.Category()))
+	}
+	if s.bot == nil {
+		return utils.Bold("%s", line)
+	}
+
+	return line
+}
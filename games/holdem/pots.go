@@ -0,0 +1,81 @@
+package holdem
+
+import (
+	"slices"
+)
+
+// returnUncalled gives back the part of the biggest bet that nobody matched.
+// Returns the seat the chips went back to and how many, or -1 if every bet was
+// called.
+func returnUncalled(seats []*seat) (int, int) {
+	top, second := -1, 0
+	for i, s := range seats {
+		switch {
+		case top == -1 || s.committed > seats[top].committed:
+			if top != -1 {
+				second = seats[top].committed
+			}
+			top = i
+		case s.committed > second:
+			second = s.committed
+		}
+	}
+
+	uncalled := seats[top].committed - second
+	if uncalled <= 0 {
+		return -1, 0
+	}
+	seats[top].committed -= uncalled
+	seats[top].stack += uncalled
+	if seats[top].stack > 0 {
+		seats[top].allIn = false
+	}
+
+	return top, uncalled
+}
+
+// buildPots splits the chips everyone has committed into the main pot and side
+// pots. A player who is all in can only win what each other player matched.
+// Folded players' chips go in the pots, but they can't win any of them.
+func buildPots(seats []*seat) []pot {
+	var levels []int
+	for _, s := range seats {
+		if s.contending() && s.committed > 0 {
+			levels = append(levels, s.committed)
+		}
+	}
+	slices.Sort(levels)
+	levels = slices.Compact(levels)
+
+	var pots []pot
+	previous := 0
+	for _, level := range levels {
+		var p pot
+		for i, s := range seats {
+			p.amount += min(s.committed, level) - min(s.committed, previous)
+			if s.contending() && s.committed >= level {
+				p.eligible = append(p.eligible, i)
+			}
+		}
+		previous = level
+
+		// A level that everyone still in reached adds to the same pot
+		if len(pots) > 0 && slices.Equal(pots[len(pots)-1].eligible, p.eligible) {
+			pots[len(pots)-1].amount += p.amount
+			continue
+		}
+		pots = append(pots, p)
+	}
+
+	// Chips folded above the last level, which can't happen once uncalled bets
+	// are returned, still belong in the pot
+	leftover := 0
+	for _, s := range seats {
+		leftover += max(0, s.committed-previous)
+	}
+	if leftover > 0 && len(pots) > 0 {
+		pots[len(pots)-1].amount += leftover
+	}
+
+	return pots
+}
@@ -0,0 +1,114 @@
+package holdem
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReturnUncalled(t *testing.T) {
+	tests := []struct {
+		name      string
+		committed []int
+		stacks    []int
+		seat      int
+		uncalled  int
+		allIn     bool // whether the seat given chips back is still all in
+	}{
+		{"every bet called", []int{50, 50, 50}, []int{100, 100, 100}, -1, 0, false},
+		{"raise nobody called", []int{100, 40, 40}, []int{200, 100, 100}, 0, 60, false},
+		{"two seats share the top bet", []int{80, 20, 80}, []int{0, 100, 0}, -1, 0, true},
+		{"short all in call", []int{30, 100}, []int{0, 400}, 1, 70, false},
+		{"all in shove over a smaller all in", []int{25, 90, 60}, []int{0, 0, 0}, 1, 30, false},
+		{"folded blinds under the bet", []int{1, 2, 15}, []int{99, 98, 85}, 2, 13, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seats := make([]*seat, len(test.committed))
+			for i := range seats {
+				seats[i] = &seat{committed: test.committed[i], stack: test.stacks[i], allIn: test.stacks[i] == 0}
+			}
+
+			got, uncalled := returnUncalled(seats)
+			if got != test.seat || uncalled != test.uncalled {
+				t.Fatalf("returnUncalled = seat %d, %d chips, want seat %d, %d chips", got, uncalled, test.seat, test.uncalled)
+			}
+			if got == -1 {
+				return
+			}
+			if want := test.committed[got] - uncalled; seats[got].committed != want {
+				t.Errorf("seat %d committed %d, want %d", got, seats[got].committed, want)
+			}
+			if want := test.stacks[got] + uncalled; seats[got].stack != want {
+				t.Errorf("seat %d stack %d, want %d", got, seats[got].stack, want)
+			}
+			if seats[got].allIn != test.allIn {
+				t.Errorf("seat %d all in = %t, want %t", got, seats[got].allIn, test.allIn)
+			}
+		})
+	}
+}
+
+func TestBuildPots(t *testing.T) {
+	tests := []struct {
+		name      string
+		committed []int
+		folded    []int
+		pots      []pot
+	}{
+		{
+			name:      "everyone called",
+			committed: []int{100, 100, 100},
+			pots:      []pot{{300, []int{0, 1, 2}}},
+		},
+		{
+			name:      "one short all in",
+			committed: []int{50, 100, 100},
+			pots:      []pot{{150, []int{0, 1, 2}}, {100, []int{1, 2}}},
+		},
+		{
+			name:      "three all ins at different levels",
+			committed: []int{25, 60, 100, 100},
+			pots:      []pot{{100, []int{0, 1, 2, 3}}, {105, []int{1, 2, 3}}, {80, []int{2, 3}}},
+		},
+		{
+			name:      "all ins at the same level share a pot",
+			committed: []int{40, 40, 40},
+			pots:      []pot{{120, []int{0, 1, 2}}},
+		},
+		{
+			name:      "odd chips from a folded seat go in the main pot",
+			committed: []int{7, 33, 50, 50},
+			folded:    []int{0},
+			pots:      []pot{{106, []int{1, 2, 3}}, {34, []int{2, 3}}},
+		},
+		{
+			name:      "folded seat that matched a side pot",
+			committed: []int{15, 60, 15, 60},
+			folded:    []int{1},
+			pots:      []pot{{60, []int{0, 2, 3}}, {90, []int{3}}},
+		},
+		{
+			name:      "chips folded above the last level",
+			committed: []int{101, 50, 50},
+			folded:    []int{0},
+			pots:      []pot{{201, []int{1, 2}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seats := make([]*seat, len(test.committed))
+			for i, committed := range test.committed {
+				seats[i] = &seat{committed: committed}
+			}
+			for _, i := range test.folded {
+				seats[i].folded = true
+			}
+
+			if pots := buildPots(seats); !reflect.DeepEqual(pots, test.pots) {
+				t.Errorf("buildPots(%v) = %v, want %v", test.committed, pots, test.pots)
+			}
+		})
+	}
+}
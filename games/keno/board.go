@@ -0,0 +1,46 @@
+package keno
+
+import (
+	"fmt"
+	"strings"
+
+	"casino/utils"
+)
+
+// boardColumns is how many numbers are on each row of the board
+const boardColumns = 10
+
+// RenderBoard draws the board like a keno ticket, 1 to 40 above 41 to 80.
+// Picked numbers are marked with a dot, drawn numbers with a ring, and picked
+// numbers that were drawn with a star.
+func RenderBoard(picked, drawn map[int]bool) []string {
+	var lines []string
+	for first := 1; first <= BoardSize; first += boardColumns {
+		if first == BoardSize/2+1 {
+			lines = append(lines, utils.Dim("%s", strings.Repeat("─", boardColumns*4)))
+		}
+
+		var row strings.Builder
+		for n := first; n < first+boardColumns; n++ {
+			row.WriteString(cell(n, picked[n], drawn[n]))
+		}
+		lines = append(lines, row.String())
+	}
+
+	return lines
+}
+
+// cell draws one number on the board with its mark
+func cell(n int, picked, drawn bool) string {
+	number := fmt.Sprintf("%3d", n)
+	switch {
+	case picked && drawn:
+		return utils.Green(utils.Bold("%s★", number))
+	case picked:
+		return utils.Cyan("%s●", number)
+	case drawn:
+		return utils.Yellow(utils.Bold("%s○", number))
+	default:
+		return utils.Dim("%s ", number)
+	}
+}
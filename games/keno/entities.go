@@ -0,0 +1,81 @@
+package keno
+
+const (
+	// BoardSize is how many numbers are on the board
+	BoardSize = 80
+	// Drawn is how many numbers come out of the hopper each game
+	Drawn = 20
+
+	MinSpots = 1
+	MaxSpots = 10
+
+	MinBet = 1
+	MaxBet = 100
+	// MaxGames is the most consecutive games one ticket can be played for
+	MaxGames = 20
+)
+
+// numbers are the numbers on the board, 1 to BoardSize
+func numbers() []int {
+	board := make([]int, BoardSize)
+	for i := range board {
+		board[i] = i + 1
+	}
+
+	return board
+}
+
+// Paytable maps how many spots were picked, then how many of them were caught,
+// to how many chips come back for each chip bet
+type Paytable map[int]map[int]int
+
+func DefaultPaytable() Paytable {
+	return Paytable{
+		1:  {1: 3},
+		2:  {2: 12},
+		3:  {2: 1, 3: 42},
+		4:  {2: 1, 3: 5, 4: 100},
+		5:  {3: 1, 4: 15, 5: 800},
+		6:  {3: 1, 4: 6, 5: 75, 6: 1500},
+		7:  {3: 1, 4: 2, 5: 18, 6: 350, 7: 5000},
+		8:  {5: 12, 6: 90, 7: 1500, 8: 15000},
+		9:  {5: 5, 6: 45, 7: 300, 8: 4000, 9: 25000},
+		10: {0: 2, 5: 2, 6: 20, 7: 120, 8: 1000, 9: 5000, 10: 50000},
+	}
+}
+
+// Pays is how many chips come back for each chip bet on a ticket with spots
+// picks that caught catch of them
+func (p Paytable) Pays(spots, catch int) int {
+	return p[spots][catch]
+}
+
+// Return is the share of every bet a ticket with spots picks pays back over time
+func (p Paytable) Return(spots int) float64 {
+	total := 0.0
+	for catch, pays := range p[spots] {
+		total += CatchChance(spots, catch) * float64(pays)
+	}
+
+	return total
+}
+
+// CatchChance is the chance that exactly catch of spots picked numbers are among
+// the numbers drawn
+func CatchChance(spots, catch int) float64 {
+	if catch < 0 || catch > spots || catch > Drawn || spots-catch > BoardSize-Drawn {
+		return 0
+	}
+
+	return choose(spots, catch) * choose(BoardSize-spots, Drawn-catch) / choose(BoardSize, Drawn)
+}
+
+// choose is the number of ways to pick k of n things
+func choose(n, k int) float64 {
+	ways := 1.0
+	for i := range k {
+		ways = ways * float64(n-i) / float64(i+1)
+	}
+
+	return ways
+}
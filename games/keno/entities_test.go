@@ -0,0 +1,61 @@
+package keno
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCatchChanceSumsToOne(t *testing.T) {
+	for spots := MinSpots; spots <= MaxSpots; spots++ {
+		total := 0.0
+		for catch := 0; catch <= spots; catch++ {
+			total += CatchChance(spots, catch)
+		}
+		if math.Abs(total-1) > 1e-12 {
+			t.Errorf("catch chances for %d spots sum to %.15f, want 1", spots, total)
+		}
+	}
+}
+
+func TestCatchChance(t *testing.T) {
+	tests := []struct {
+		spots, catch int
+		chance       float64
+	}{
+		{1, 1, 0.25},
+		{1, 0, 0.75},
+		{2, 2, 190.0 / 3160},
+		{10, 10, 184756.0 / 1646492110120},
+		{1, 2, 0},
+		{3, -1, 0},
+	}
+
+	for _, test := range tests {
+		if got := CatchChance(test.spots, test.catch); math.Abs(got-test.chance) > 1e-12 {
+			t.Errorf("CatchChance(%d, %d) = %g, want %g", test.spots, test.catch, got, test.chance)
+		}
+	}
+}
+
+func TestDefaultPaytableReturn(t *testing.T) {
+	// Worked out separately from the hypergeometric catch chances
+	want := map[int]float64{
+		1:  0.7500000000,
+		2:  0.7215189873,
+		3:  0.7215189873,
+		4:  0.7352141529,
+		5:  0.7812598794,
+		6:  0.7266973533,
+		7:  0.8131078808,
+		8:  0.7385029107,
+		9:  0.7463677765,
+		10: 0.7889950345,
+	}
+
+	paytable := DefaultPaytable()
+	for spots := MinSpots; spots <= MaxSpots; spots++ {
+		if got := paytable.Return(spots); math.Abs(got-want[spots]) > 1e-9 {
+			t.Errorf("Return(%d) = %.10f, want %.10f", spots, got, want[spots])
+		}
+	}
+}
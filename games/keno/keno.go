@@ -0,0 +1,386 @@
+package keno
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"casino/games"
+	"casino/utils"
+)
+
+const (
+	// ballDelay is how long each ball takes to come out of the hopper
+	ballDelay = 80 * time.Millisecond
+	// gameDelay is the pause between consecutive games so each result can be seen
+	gameDelay = 2 * time.Second
+)
+
+type keno struct {
+	saveManager utils.SaveDataManager
+	paytable    Paytable
+
+	picks     []int // the player's spots, in the order they were picked
+	bet       int
+	userChips int
+	messages  []string // feedback on the last command, shown under the ticket
+
+	in  chan string
+	out chan string
+}
+
+func NewKeno(
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	k := &keno{
+		saveManager: saveManager,
+		paytable:    DefaultPaytable(),
+		in:          in,
+		out:         out,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
+}
+
+// Option configures a keno game
+type Option func(*keno)
+
+// WithPaytable sets what each ticket pays
+func WithPaytable(paytable Paytable) Option {
+	return func(k *keno) { k.paytable = paytable }
+}
+
+func (k *keno) Name() string {
+	return "Keno"
+}
+
+// Play runs tickets until the player chooses to leave. Every ticket returns
+// here before the next one starts, and the player's numbers are kept for it.
+func (k *keno) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := k.saveManager.Read().RemainingChips
+
+	for {
+		played, err := k.playRound(ctx)
+		result.Rounds += played
+
+		again := false
+		if err == nil {
+			again, err = k.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = k.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the player's spots, bet and number of games, then plays the
+// games one after another. Returns how many games were played. Each game's bet
+// is only taken when it starts, so if ctx is canceled the game being drawn is
+// finished and the rest are never played.
+func (k *keno) playRound(ctx context.Context) (int, error) {
+	k.userChips = k.saveManager.Read().RemainingChips
+	if k.userChips < MinBet {
+		utils.Clear(k.out)
+		utils.PrintBanner(k.Name(), k.out)
+		k.out <- utils.Red("You need at least %d chips to play", MinBet)
+		return 0, nil
+	}
+
+	if err := k.pickSpots(ctx); err != nil {
+		return 0, err
+	}
+	count, err := k.placeBet(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	played, won := 0, 0
+	for played < count {
+		if played > 0 {
+			if ctx.Err() != nil {
+				break
+			}
+			utils.Sleep(ctx, gameDelay)
+		}
+		won += k.playGame(ctx, fmt.Sprintf("Game %d of %d", played+1, count))
+		played++
+	}
+	if played > 1 {
+		k.out <- utils.Bold("%d games won %d chips for %d chips bet", played, won, played*k.bet)
+	}
+
+	return played, nil
+}
+
+// pickSpots lets the player mark numbers on the ticket until they're ready to
+// play. Numbers picked for the last ticket are kept.
+func (k *keno) pickSpots(ctx context.Context) error {
+	k.messages = []string{utils.Dim("Type help to see how to pick")}
+
+	for {
+		k.printTicket()
+		k.out <- fmt.Sprintf("Pick %d-%d numbers (e.g. 7 23 61), quick pick (qp 6), clear, play (p) or help", MinSpots, MaxSpots)
+		line, err := utils.ReadLine(ctx, k.in)
+		if err != nil {
+			return err
+		}
+
+		k.messages = nil
+		words := strings.Fields(strings.ToLower(strings.ReplaceAll(line, ",", " ")))
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "play", "p":
+			if len(k.picks) >= MinSpots {
+				return nil
+			}
+			k.messages = append(k.messages, utils.Yellow("Pick at least %d number first", MinSpots))
+		case "qp", "quick":
+			k.quickPick(words[1:])
+		case "clear":
+			k.picks = nil
+		case "help":
+			k.messages = append(k.messages,
+				"7 23 61      pick numbers, or take them back off the ticket",
+				"qp 6         quick pick 6 numbers at random, replacing your picks",
+				"clear        take every number off the ticket",
+				fmt.Sprintf("p            play the ticket. %d numbers are drawn and you're paid on how many of yours come up", Drawn),
+			)
+		default:
+			k.toggle(words)
+		}
+	}
+}
+
+// toggle picks each number, or takes it off the ticket if it was already picked
+func (k *keno) toggle(words []string) {
+	for _, word := range words {
+		n, err := strconv.Atoi(word)
+		if err != nil || n < 1 || n > BoardSize {
+			k.messages = append(k.messages, utils.Red("%q isn't a number from 1 to %d", word, BoardSize))
+			continue
+		}
+
+		if i := slices.Index(k.picks, n); i >= 0 {
+			k.picks = slices.Delete(k.picks, i, i+1)
+			continue
+		}
+		if len(k.picks) >= MaxSpots {
+			k.messages = append(k.messages, utils.Yellow("You can pick at most %d numbers", MaxSpots))
+			return
+		}
+		k.picks = append(k.picks, n)
+	}
+}
+
+// quickPick replaces the player's picks with numbers picked at random. Without
+// a count it picks as many as the player had, or MaxSpots if they had none.
+func (k *keno) quickPick(words []string) {
+	spots := len(k.picks)
+	if spots == 0 {
+		spots = MaxSpots
+	}
+	if len(words) > 0 {
+		n, err := strconv.Atoi(words[0])
+		if err != nil || n < MinSpots || n > MaxSpots {
+			k.messages = append(k.messages, utils.Red("Quick pick %d to %d numbers, e.g. qp 6", MinSpots, MaxSpots))
+			return
+		}
+		spots = n
+	}
+
+	k.picks = utils.Sample(numbers(), spots)
+	slices.Sort(k.picks)
+}
+
+// placeBet asks how much to bet a game and how many games to play. Returns the
+// number of games.
+func (k *keno) placeBet(ctx context.Context) (int, error) {
+	maxBet := min(MaxBet, k.userChips)
+	bet, err := utils.GetBet(
+		ctx,
+		k.in,
+		k.out,
+		fmt.Sprintf("How much a game? (%d-%d)", MinBet, maxBet),
+		MinBet,
+		maxBet,
+	)
+	if err != nil {
+		return 0, err
+	}
+	k.bet = bet
+
+	maxGames := min(MaxGames, k.userChips/k.bet)
+	if maxGames == 1 {
+		return 1, nil
+	}
+
+	return utils.GetBet(
+		ctx,
+		k.in,
+		k.out,
+		fmt.Sprintf("How many games in a row? (1-%d, %d chips a game)", maxGames, k.bet),
+		1,
+		maxGames,
+	)
+}
+
+// playGame takes the bet, draws the balls one by one and pays the ticket.
+// Returns how much it won. The draw isn't animated if ctx is canceled.
+func (k *keno) playGame(ctx context.Context, status string) int {
+	save := k.saveManager.Read()
+	save.RemainingChips -= k.bet
+	k.saveManager.Save(save)
+	k.userChips = save.RemainingChips
+
+	balls := utils.Sample(numbers(), Drawn)
+
+	utils.Clear(k.out)
+	k.out <- utils.Dim("Your chips: %d", k.userChips)
+	k.out <- utils.Dim("Bet: %d on %d spots", k.bet, len(k.picks))
+	k.out <- utils.Bold("%s", status)
+	k.out <- utils.Divider()
+
+	picked := k.pickedSet()
+	drawn := map[int]bool{}
+	height := 0
+	for i, ball := range balls {
+		drawn[ball] = true
+		lines := append(RenderBoard(picked, drawn), k.describeCatch(drawn, i+1))
+		if height == 0 {
+			for _, line := range lines {
+				k.out <- line
+			}
+		} else {
+			k.out <- utils.Redraw(height, lines)
+		}
+		height = len(lines)
+
+		if i < len(balls)-1 {
+			utils.Sleep(ctx, ballDelay)
+		}
+	}
+	k.out <- utils.Divider()
+
+	catch := k.catch(drawn)
+	won := k.paytable.Pays(len(k.picks), catch) * k.bet
+	if won == 0 {
+		k.out <- utils.Red("No win (-%d chips)", k.bet)
+		return 0
+	}
+
+	save = k.saveManager.Read()
+	save.RemainingChips += won
+	k.saveManager.Save(save)
+	k.userChips = save.RemainingChips
+
+	k.out <- utils.Green(utils.Bold("Catch %d of %d pays %d chips!", catch, len(k.picks), won))
+	return won
+}
+
+// describeCatch says how many of the player's numbers are among the balls drawn
+// so far
+func (k *keno) describeCatch(drawn map[int]bool, balls int) string {
+	return fmt.Sprintf("Ball %d of %d, caught %d of %d", balls, Drawn, k.catch(drawn), len(k.picks))
+}
+
+// catch counts the player's numbers that were drawn
+func (k *keno) catch(drawn map[int]bool) int {
+	caught := 0
+	for _, n := range k.picks {
+		if drawn[n] {
+			caught++
+		}
+	}
+
+	return caught
+}
+
+func (k *keno) pickedSet() map[int]bool {
+	picked := map[int]bool{}
+	for _, n := range k.picks {
+		picked[n] = true
+	}
+
+	return picked
+}
+
+// playAgain asks whether the player wants another ticket. Returns false if they
+// want to leave.
+func (k *keno) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		k.in,
+		k.out,
+		[]string{"yes", "y", "no", "n"},
+		"Play again? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// printTicket shows the board with the player's picks, what the ticket pays and
+// any messages for the player
+func (k *keno) printTicket() {
+	utils.Clear(k.out)
+	k.out <- utils.Dim("Your chips: %d", k.userChips)
+
+	picks := slices.Sorted(slices.Values(k.picks))
+	k.out <- utils.Dim("Your numbers (%d): %s", len(picks), strings.Trim(fmt.Sprint(picks), "[]"))
+	k.out <- utils.Divider()
+
+	for _, line := range RenderBoard(k.pickedSet(), nil) {
+		k.out <- line
+	}
+	k.out <- utils.Divider()
+
+	if len(k.picks) > 0 {
+		for _, line := range k.renderPaytable(len(k.picks)) {
+			k.out <- line
+		}
+		k.out <- utils.Divider()
+	}
+	for _, message := range k.messages {
+		k.out <- message
+	}
+}
+
+// renderPaytable shows what each catch pays for a ticket with spots picks
+func (k *keno) renderPaytable(spots int) []string {
+	catches := slices.Sorted(maps.Keys(k.paytable[spots]))
+
+	catchRow := utils.PadRight("Catch", 8)
+	paysRow := utils.PadRight("Pays", 8)
+	for _, catch := range catches {
+		catchRow += fmt.Sprintf("%7d", catch)
+		paysRow += fmt.Sprintf("%7d", k.paytable.Pays(spots, catch))
+	}
+
+	return []string{
+		utils.Bold("%s", catchRow),
+		paysRow,
+		utils.Dim("Pays for each chip bet on %d spots, returning %.1f%% over time", spots, k.paytable.Return(spots)*100),
+	}
+}
@@ -0,0 +1,85 @@
+package keno
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// spots are the numbers 1 to n
+func spots(n int) []int {
+	return numbers()[:n]
+}
+
+func TestToggle(t *testing.T) {
+	tests := []struct {
+		name     string
+		picks    []int
+		command  string
+		want     []int
+		messages int
+	}{
+		{"pick numbers", nil, "7 23", []int{7, 23}, 0},
+		{"take a number back", []int{7, 23}, "7", []int{23}, 0},
+		{"pick and take back together", []int{7}, "7 80 1", []int{80, 1}, 0},
+		{"out of range", []int{7}, "0 81", []int{7}, 2},
+		{"not a number", []int{7}, "seven", []int{7}, 1},
+		{"bad numbers don't stop the good ones", nil, "5 x 6", []int{5, 6}, 1},
+		{"no more than MaxSpots", spots(MaxSpots), "11", spots(MaxSpots), 1},
+		{"stops at MaxSpots", spots(MaxSpots - 1), "20 21", append(spots(MaxSpots-1), 20), 1},
+		{"take back at MaxSpots", spots(MaxSpots), "10", spots(MaxSpots - 1), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k := &keno{picks: slices.Clone(test.picks)}
+			k.toggle(strings.Fields(test.command))
+			if !slices.Equal(k.picks, test.want) {
+				t.Errorf("toggle %q on %v picked %v, want %v", test.command, test.picks, k.picks, test.want)
+			}
+			if len(k.messages) != test.messages {
+				t.Errorf("toggle %q gave messages %q, want %d", test.command, k.messages, test.messages)
+			}
+		})
+	}
+}
+
+func TestQuickPick(t *testing.T) {
+	tests := []struct {
+		name    string
+		picks   []int
+		command string
+		want    int // how many numbers are picked, or -1 if the picks shouldn't change
+	}{
+		{"fills the ticket", nil, "", MaxSpots},
+		{"as many as were picked", []int{7, 23, 61, 80}, "", 4},
+		{"a given count", []int{7}, "6", 6},
+		{"one number", nil, "1", 1},
+		{"too few", []int{7}, "0", -1},
+		{"too many", []int{7}, "11", -1},
+		{"not a number", []int{7}, "six", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k := &keno{picks: slices.Clone(test.picks)}
+			k.quickPick(strings.Fields(test.command))
+
+			if test.want < 0 {
+				if !slices.Equal(k.picks, test.picks) || len(k.messages) != 1 {
+					t.Errorf("quick pick %q changed %v to %v with messages %q, want an error", test.command, test.picks, k.picks, k.messages)
+				}
+				return
+			}
+			if len(k.picks) != test.want || len(k.messages) != 0 {
+				t.Fatalf("quick pick %q picked %v with messages %q, want %d numbers", test.command, k.picks, k.messages, test.want)
+			}
+			if !slices.IsSorted(k.picks) || k.picks[0] < 1 || k.picks[len(k.picks)-1] > BoardSize {
+				t.Errorf("quick pick %q picked %v, want sorted numbers from 1 to %d", test.command, k.picks, BoardSize)
+			}
+			if len(slices.Compact(slices.Clone(k.picks))) != len(k.picks) {
+				t.Errorf("quick pick %q picked %v, want no repeats", test.command, k.picks)
+			}
+		})
+	}
+}
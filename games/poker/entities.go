@@ -1,14 +1,21 @@
 package poker
 
-type PokerHand int
+import "casino/evaluator"
+
+// MinChips is the fewest chips a hand can be played with: the smallest ante,
+// and the same again kept back for the play bet
+const MinChips = 2
+
+// PokerHand is a 3-card poker hand, from worst to best
+type PokerHand = evaluator.ThreeCardCategory
 
 const (
-	HighCard      PokerHand = 0
-	Pair          PokerHand = 1
-	Flush         PokerHand = 2
-	Straight      PokerHand = 3
-	ThreeOfAKind  PokerHand = 4
-	StraightFlush PokerHand = 5
+	HighCard      = evaluator.ThreeCardHighCard
+	Pair          = evaluator.ThreeCardPair
+	Flush         = evaluator.ThreeCardFlush
+	Straight      = evaluator.ThreeCardStraight
+	ThreeOfAKind  = evaluator.ThreeCardThreeOfAKind
+	StraightFlush = evaluator.ThreeCardStraightFlush
 )
 
 var PokerHandToString = map[PokerHand]string{
@@ -27,3 +34,37 @@ var PokerHandToPairPlusMultiplier = map[PokerHand]int{
 	ThreeOfAKind:  30,
 	StraightFlush: 40,
 }
+
+var PokerHandToAnteBonusMultiplier = map[PokerHand]int{
+	Straight:      1,
+	ThreeOfAKind:  4,
+	StraightFlush: 5,
+}
+
+// SixCardBonusMultiplier pays the best five-card hand made from the player's and
+// dealer's cards together
+var SixCardBonusMultiplier = map[evaluator.Category]int{
+	evaluator.ThreeOfAKind:  5,
+	evaluator.Straight:      10,
+	evaluator.Flush:         15,
+	evaluator.FullHouse:     25,
+	evaluator.FourOfAKind:   50,
+	evaluator.StraightFlush: 200,
+	evaluator.RoyalFlush:    1000,
+}
+
+// Paytable is what each hand pays on the bonus bets, as X to 1. Hands that
+// aren't listed lose the bet.
+type Paytable struct {
+	PairPlus     map[PokerHand]int
+	AnteBonus    map[PokerHand]int
+	SixCardBonus map[evaluator.Category]int
+}
+
+func DefaultPaytable() Paytable {
+	return Paytable{
+		PairPlus:     PokerHandToPairPlusMultiplier,
+		AnteBonus:    PokerHandToAnteBonusMultiplier,
+		SixCardBonus: SixCardBonusMultiplier,
+	}
+}
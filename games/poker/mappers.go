@@ -1,95 +1,39 @@
 package poker
 
 import (
-	"casino/entities"
-	"casino/utils"
 	"slices"
-)
-
-func DeterminePokerHandLevel(hand entities.Hand) PokerHand {
-	cards := hand.Cards
-	slices.SortFunc(cards, func(card1, card2 entities.Card) int {
-		return card1.SortValue - card2.SortValue
-	})
-	isStraight := true
-	isFlush := true
-	for i := range cards[:len(cards)-1] {
-		card := cards[i]
-		nextCard := cards[i+1]
-		if card.SortValue+1 != nextCard.SortValue {
-			isStraight = false
-		}
-		if card.Suit != nextCard.Suit {
-			isFlush = false
-		}
-	}
-
-	if isFlush && isStraight {
-		return StraightFlush
-	}
-
-	uniqueCardValues := make(map[int]struct{}, len(hand.Cards))
-	for _, card := range cards {
-		uniqueCardValues[card.SortValue] = struct{}{}
-	}
-	if len(uniqueCardValues) == 1 {
-		return ThreeOfAKind
-	}
 
-	if isStraight {
-		return Straight
-	}
-
-	if isFlush {
-		return Flush
-	}
+	"casino/entities"
+	"casino/evaluator"
+)
 
-	if len(uniqueCardValues) == 2 {
-		return Pair
-	}
+// qualifyingRank is the lowest high card the dealer needs to qualify: a queen
+const qualifyingRank = 11
 
-	return HighCard
+// RankHand ranks a 3-card poker hand. Better hands have higher ranks.
+func RankHand(hand entities.Hand) evaluator.Rank {
+	return evaluator.ThreeCard(hand.Cards)
 }
 
-func ResolvePush(hands map[entities.Role]entities.Hand, handLevel PokerHand) (entities.Role, bool) {
-	dealerHand := hands[entities.DealerRole]
-	userHand := hands[entities.UserRole]
-
-	if handLevel == Pair {
-		// Figure out what the pair is
-		dealerPairValue := GetPairValues(dealerHand)
-		userPairValue := GetPairValues(userHand)
-		if dealerPairValue > userPairValue {
-			return entities.DealerRole, true
-		} else if dealerPairValue < userPairValue {
-			return entities.UserRole, true
-		} else {
-			return entities.Role("none"), false
-		}
-	}
-
-	// Otherwise, we can just figure out what the highest card is
-	dealerHighest, _ := utils.MaxFunc(dealerHand.Cards, func(card entities.Card) int {
-		return card.SortValue
-	})
-	userHighest, _ := utils.MaxFunc(userHand.Cards, func(card entities.Card) int {
-		return card.SortValue
-	})
+// DeterminePokerHandLevel works out the 3-card poker hand
+func DeterminePokerHandLevel(hand entities.Hand) PokerHand {
+	return PokerHand(RankHand(hand).Category())
+}
 
-	if dealerHighest.SortValue > userHighest.SortValue {
-		return entities.DealerRole, true
-	}
-	if dealerHighest.SortValue < userHighest.SortValue {
-		return entities.UserRole, true
+// DealerQualifies reports whether the dealer's hand is good enough to play
+// against the player: queen high or better
+func DealerQualifies(hand entities.Hand) bool {
+	rank := RankHand(hand)
+	if PokerHand(rank.Category()) > HighCard {
+		return true
 	}
 
-	return entities.Role("none"), false
+	return rank.Kickers()[0] >= qualifyingRank
 }
 
-func GetPairValues(hand entities.Hand) int {
-	if hand.Cards[0].SortValue == hand.Cards[1].SortValue {
-		return hand.Cards[0].SortValue
-	}
-
-	return hand.Cards[2].SortValue
+// HighestCard returns the highest card in the hand, aces high
+func HighestCard(hand entities.Hand) entities.Card {
+	return slices.MaxFunc(hand.Cards, func(card1, card2 entities.Card) int {
+		return evaluator.CardRank(card1) - evaluator.CardRank(card2)
+	})
 }
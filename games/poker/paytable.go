@@ -0,0 +1,80 @@
+package poker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"casino/evaluator"
+)
+
+// paytableFile is a paytable as it's written in JSON, with hands by name
+type paytableFile struct {
+	PairPlus     map[string]int `json:"pairPlus"`
+	AnteBonus    map[string]int `json:"anteBonus"`
+	SixCardBonus map[string]int `json:"sixCardBonus"`
+}
+
+// LoadPaytable reads a paytable from a JSON file. Hands are named the way the
+// table shows them, in any case, e.g. {"pairPlus": {"straight flush": 40}}. A
+// bet that's in the file is paid only on the hands listed for it, and a bet
+// that's left out keeps its default payouts.
+func LoadPaytable(path string) (Paytable, error) {
+	paytable := DefaultPaytable()
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return paytable, err
+	}
+	var file paytableFile
+	if err := json.Unmarshal(bytes, &file); err != nil {
+		return paytable, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	sixCardNames := map[evaluator.Category]string{}
+	for category := evaluator.HighCard; category <= evaluator.RoyalFlush; category++ {
+		sixCardNames[category] = category.String()
+	}
+
+	if file.PairPlus != nil {
+		if paytable.PairPlus, err = lookupHands("pairPlus", file.PairPlus, PokerHandToString); err != nil {
+			return paytable, err
+		}
+	}
+	if file.AnteBonus != nil {
+		if paytable.AnteBonus, err = lookupHands("anteBonus", file.AnteBonus, PokerHandToString); err != nil {
+			return paytable, err
+		}
+	}
+	if file.SixCardBonus != nil {
+		if paytable.SixCardBonus, err = lookupHands("sixCardBonus", file.SixCardBonus, sixCardNames); err != nil {
+			return paytable, err
+		}
+	}
+
+	return paytable, nil
+}
+
+// lookupHands swaps the hand names in a bet's payouts for the hands they name.
+// Every hand listed has to pay at least 1 to 1.
+func lookupHands[H comparable](bet string, pays map[string]int, names map[H]string) (map[H]int, error) {
+	hands := map[H]int{}
+	for name, multiplier := range pays {
+		found := false
+		for hand, handName := range names {
+			if strings.EqualFold(strings.TrimSpace(name), handName) {
+				hands[hand] = multiplier
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s has unknown hand %q", bet, name)
+		}
+		if multiplier < 1 {
+			return nil, fmt.Errorf("%s must pay at least 1 to 1 on %s, got %d", bet, name, multiplier)
+		}
+	}
+
+	return hands, nil
+}
@@ -1,9 +1,12 @@
 package poker
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
 	"casino/entities"
+	"casino/evaluator"
 	"casino/games"
 	"casino/utils"
 )
@@ -19,16 +22,16 @@ const (
 type poker struct {
 	dealer      utils.Dealer
 	saveManager utils.SaveDataManager
+	paytable    Paytable
 
 	hands     map[entities.Role]entities.Hand
 	userChips int
 	ante      int
 	pairPlus  int
+	sixCard   int
 
 	in  chan string
 	out chan string
-
-	quit func()
 }
 
 func NewPoker(
@@ -36,67 +39,150 @@ func NewPoker(
 	saveManager utils.SaveDataManager,
 	in chan string,
 	out chan string,
-	quit func(),
+	opts ...Option,
 ) games.Game {
-	return &poker{
+	p := &poker{
 		dealer:      dealer,
 		saveManager: saveManager,
+		paytable:    DefaultPaytable(),
 		in:          in,
 		out:         out,
-		quit:        quit,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Option configures a 3-card poker game
+type Option func(*poker)
+
+// WithPaytable sets what the pair plus and ante bonus bets pay
+func WithPaytable(paytable Paytable) Option {
+	return func(p *poker) { p.paytable = paytable }
 }
 
 func (p *poker) Name() string {
 	return "3-Card Poker"
 }
 
-func (p *poker) Play() {
+// Play runs hands until the player chooses to leave the table. Every hand
+// returns here before the next one starts.
+func (p *poker) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := p.saveManager.Read().RemainingChips
+
+	for {
+		played, err := p.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = p.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = p.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the player's bets, plays one hand and settles it. Returns
+// whether a hand was dealt. Once the cards are out the hand is always settled,
+// even if ctx is canceled part way through.
+func (p *poker) playRound(ctx context.Context) (bool, error) {
 	utils.Clear(p.out)
 	save := p.saveManager.Read()
 	p.userChips = save.RemainingChips
 
 	utils.PrintBanner(p.Name(), p.out)
-	p.wager()
+	if p.dealer.NeedsShuffle() {
+		p.out <- utils.Yellow("Cut card is out, shuffling the deck...")
+		p.dealer.Shuffle()
+	}
+	if p.userChips < MinChips {
+		p.out <- utils.Red("You need at least %d chips to play", MinChips)
+		return false, nil
+	}
+	if err := p.wager(ctx); err != nil {
+		return false, err
+	}
+
+	if err := p.deal(); err != nil {
+		p.misdeal(err)
+		return true, nil
+	}
+
+	folded := p.lastChance(ctx)
+	p.compareHands(folded)
+	p.endGame()
+	return true, nil
 }
 
-func (p *poker) wager() {
+// wager takes the ante, pair plus and 6-card bonus bets. Nothing is taken from
+// the player's chips unless all the bets are placed.
+func (p *poker) wager(ctx context.Context) error {
 	save := p.saveManager.Read()
+	p.out <- utils.Dim("Dealer qualifies with queen high or better")
+	p.printPaytable("Ante bonus payouts, win or lose:", p.paytable.AnteBonus)
 	p.out <- utils.Dim(fmt.Sprintf("You have %d chips", save.RemainingChips))
-	p.ante = utils.GetBet(p.in, p.out, fmt.Sprintf("How much do you want to ante? (max %d)", p.userChips/2), 1, p.userChips/2)
+	ante, err := utils.GetBet(ctx, p.in, p.out, fmt.Sprintf("How much do you want to ante? (max %d)", p.userChips/2), 1, p.userChips/2)
+	if err != nil {
+		return err
+	}
+	p.ante = ante
 	p.userChips -= p.ante
-	p.out <- "Pair plus payouts:"
-	for hand, multiplier := range PokerHandToPairPlusMultiplier {
-		p.out <- fmt.Sprintf("\t%s: %d to 1", PokerHandToString[hand], multiplier)
+	p.printPaytable("Pair plus payouts:", p.paytable.PairPlus)
+	pairPlus, err := utils.GetBet(ctx, p.in, p.out, fmt.Sprintf("Pair plus? (max %d)", p.userChips/2), 0, p.userChips/2)
+	if err != nil {
+		p.userChips = save.RemainingChips
+		return err
 	}
-	p.pairPlus = utils.GetBet(p.in, p.out, fmt.Sprintf("Pair plus? (max %d)", p.userChips/2), 0, p.userChips/2)
+	p.pairPlus = pairPlus
 	p.userChips -= p.pairPlus
 
+	// Whatever is left after keeping enough back for the play bet
+	p.out <- "6-card bonus payouts, your cards and the dealer's:"
+	for category := evaluator.RoyalFlush; category >= evaluator.HighCard; category-- {
+		if multiplier, ok := p.paytable.SixCardBonus[category]; ok {
+			p.out <- fmt.Sprintf("\t%s: %d to 1", category, multiplier)
+		}
+	}
+	maxSixCard := max(p.userChips-p.ante, 0)
+	sixCard, err := utils.GetBet(ctx, p.in, p.out, fmt.Sprintf("6-card bonus? (max %d)", maxSixCard), 0, maxSixCard)
+	if err != nil {
+		p.userChips = save.RemainingChips
+		return err
+	}
+	p.sixCard = sixCard
+	p.userChips -= p.sixCard
+
 	save.RemainingChips = p.userChips
 	p.saveManager.Save(save)
 
-	p.deal()
+	return nil
 }
 
-func (p *poker) deal() {
-	p.out <- utils.Dim("Shuffling the deck...")
-	p.dealer.Shuffle()
+// deal gives the player and dealer three cards each and pays the pair plus bet
+func (p *poker) deal() error {
 	p.hands = map[entities.Role]entities.Hand{}
 
-	// Create dealer initial hand and user initial hand
-	for i := range 6 {
-		role := entities.DealerRole
-		hidden := true
-		if i%2 == 0 {
-			role = entities.UserRole
-			hidden = false
-		}
-
-		hand := p.hands[role]
-		card := p.dealer.Draw()
-		card.Hidden = hidden
-		hand.Cards = append(hand.Cards, card)
-		p.hands[role] = hand
+	// Deal three cards face up to the user and three face down to the dealer
+	userCards, err := p.dealer.DrawCards(entities.DrawOpts{Count: 3})
+	p.hands[entities.UserRole] = entities.Hand{Cards: userCards}
+	if err != nil {
+		return err
+	}
+	dealerCards, err := p.dealer.DrawCards(entities.DrawOpts{Count: 3})
+	p.hands[entities.DealerRole] = entities.Hand{Cards: dealerCards}
+	if err != nil {
+		return err
+	}
+	for i := range dealerCards {
+		dealerCards[i].Hidden = true
 	}
 
 	p.printUpdate()
@@ -105,11 +191,38 @@ func (p *poker) deal() {
 		p.payoutPairPlus(userHandLevel)
 	}
 
-	p.lastChance()
+	return nil
+}
+
+// misdeal calls off the hand when the dealer can't deal, giving back all the
+// bets
+func (p *poker) misdeal(err error) {
+	refund := p.ante + p.pairPlus + p.sixCard
+	save := p.saveManager.Read()
+	save.RemainingChips += refund
+	p.saveManager.Save(save)
+	p.userChips = save.RemainingChips
+
+	p.out <- utils.Red("Misdeal: %s", err.Error())
+	p.out <- utils.Yellow("All wagers returned (+%d chips)", refund)
+
+	// The next hand checks the cut card, which an empty deck is well past, so it
+	// starts from a fresh shuffle
+	p.endGame()
+}
+
+// printPaytable lists the payouts for a bonus bet, best hand first
+func (p *poker) printPaytable(title string, paytable map[PokerHand]int) {
+	p.out <- title
+	for hand := StraightFlush; hand >= HighCard; hand-- {
+		if multiplier, ok := paytable[hand]; ok {
+			p.out <- fmt.Sprintf("\t%s: %d to 1", PokerHandToString[hand], multiplier)
+		}
+	}
 }
 
 func (p *poker) payoutPairPlus(level PokerHand) {
-	multiplier := PokerHandToPairPlusMultiplier[level]
+	multiplier := p.paytable.PairPlus[level]
 	bonus := p.pairPlus * multiplier
 
 	if multiplier == 0 {
@@ -120,19 +233,54 @@ func (p *poker) payoutPairPlus(level PokerHand) {
 	p.out <- utils.Dim("%s pays out %d to 1", PokerHandToString[level], multiplier)
 	p.out <- utils.Green(utils.Bold("You win your pair plus bet! (+%d chips)", bonus))
 
+	// The winnings come with the pair plus bet itself
+	save := p.saveManager.Read()
+	save.RemainingChips += p.pairPlus + bonus
+	p.saveManager.Save(save)
+	p.userChips = save.RemainingChips
+}
+
+// payoutSixCardBonus pays the 6-card bonus on the best five cards from the
+// player's and dealer's hands
+func (p *poker) payoutSixCardBonus() {
+	cards := append(
+		slices.Clone(p.hands[entities.UserRole].Cards),
+		p.hands[entities.DealerRole].Cards...,
+	)
+	rank, best := evaluator.BestFive(cards)
+	category := evaluator.Category(rank.Category())
+	p.out <- utils.Dim("Best five cards:%s (%s)", entities.Hand{Cards: best}, category)
+
+	multiplier, ok := p.paytable.SixCardBonus[category]
+	if !ok {
+		p.out <- utils.Red("You lose your 6-card bonus bet (-%d chips)", p.sixCard)
+		return
+	}
+
+	bonus := p.sixCard * multiplier
+	p.out <- utils.Dim("%s pays out %d to 1", category, multiplier)
+	p.out <- utils.Green(utils.Bold("You win your 6-card bonus bet! (+%d chips)", bonus))
+
+	// The winnings come with the 6-card bonus bet itself
 	save := p.saveManager.Read()
-	save.RemainingChips += p.pairPlus * multiplier
+	save.RemainingChips += p.sixCard + bonus
 	p.saveManager.Save(save)
 	p.userChips = save.RemainingChips
 }
 
-func (p *poker) lastChance() {
-	userChoice := utils.GetInput(
+// lastChance asks the player to play or fold. Returns true if they folded, which
+// is also what happens if they stop answering.
+func (p *poker) lastChance(ctx context.Context) bool {
+	userChoice, err := utils.GetInput(
+		ctx,
 		p.in,
 		p.out,
 		[]string{"play", "p", "fold", "f"},
 		fmt.Sprintf("Play (p, %d chips) or fold (f)?", p.ante),
 	)
+	if err != nil {
+		return true
+	}
 
 	var folded bool
 	switch userChoice {
@@ -145,7 +293,8 @@ func (p *poker) lastChance() {
 	case "fold", "f":
 		folded = true
 	}
-	p.compareHands(folded)
+
+	return folded
 }
 
 func (p *poker) compareHands(folded bool) {
@@ -158,79 +307,92 @@ func (p *poker) compareHands(folded bool) {
 		p.out <- utils.Red("You %s", utils.Bold("folded"))
 	}
 
-	dealerLevel := DeterminePokerHandLevel(p.hands[entities.DealerRole])
-	userLevel := DeterminePokerHandLevel(p.hands[entities.UserRole])
+	dealerRank := RankHand(p.hands[entities.DealerRole])
+	userRank := RankHand(p.hands[entities.UserRole])
+	dealerLevel := PokerHand(dealerRank.Category())
+	userLevel := PokerHand(userRank.Category())
 
 	dealerStr := fmt.Sprintf("Dealer has %s", PokerHandToString[dealerLevel])
 	if dealerLevel == HighCard {
-		dealerHigh, _ := utils.MaxFunc(p.hands[entities.DealerRole].Cards, func(c entities.Card) int {
-			return c.SortValue
-		})
+		dealerHigh := HighestCard(p.hands[entities.DealerRole])
 		dealerStr += fmt.Sprintf(" (%s high)", dealerHigh.Rank)
 	}
 	p.out <- utils.Dim(dealerStr)
 	userStr := fmt.Sprintf("You have %s", PokerHandToString[userLevel])
 	if userLevel == HighCard {
-		userHigh, _ := utils.MaxFunc(p.hands[entities.UserRole].Cards, func(c entities.Card) int {
-			return c.SortValue
-		})
+		userHigh := HighestCard(p.hands[entities.UserRole])
 		userStr += fmt.Sprintf(" (%s high)", userHigh.Rank)
 	}
 	p.out <- utils.Dim(userStr)
 
+	// The 6-card bonus is paid whether or not the player folded
+	if p.sixCard > 0 {
+		p.payoutSixCardBonus()
+	}
+
+	if folded {
+		p.out <- utils.Red(utils.Bold("Dealer wins (-%d chips)", p.ante))
+		return
+	}
+
+	// Everything returned to the player, including the ante and play bets
+	// themselves when they win or push
 	bonus := 0
-	if userLevel-dealerLevel < 0 {
-		loss := p.ante * 2
-		if folded {
-			loss = p.ante
-		}
-		p.out <- utils.Red(utils.Bold("Dealer wins (-%d chips)", loss))
-	} else if userLevel-dealerLevel == 0 {
-		winner, _ := ResolvePush(p.hands, userLevel)
-		if folded || winner == entities.DealerRole {
-			loss := p.ante * 2
-			if folded {
-				loss = p.ante
-			}
-			p.out <- utils.Red(utils.Bold("Dealer wins (-%d chips)", loss))
-		} else if winner == entities.UserRole {
-			bonus = p.ante * 3
+	if !DealerQualifies(p.hands[entities.DealerRole]) {
+		bonus = p.ante*2 + p.ante
+		p.out <- utils.Green("Dealer doesn't qualify, ante pays 1 to 1 and play pushes (+%d chips)", bonus)
+	} else {
+		switch {
+		case userRank > dealerRank:
+			bonus = p.ante * 4
 			p.out <- utils.Green("You win! (+%d chips)", bonus)
-		} else {
-			p.out <- "Push, you get your chips back!"
+		case userRank < dealerRank:
+			p.out <- utils.Red(utils.Bold("Dealer wins (-%d chips)", p.ante*2))
+		default:
 			bonus = p.ante * 2
+			p.out <- "Push, you get your chips back!"
 		}
-	} else {
-		bonus = p.ante * 3
-		p.out <- utils.Green("You win! (+%d chips)", bonus)
+	}
+
+	if multiplier, ok := p.paytable.AnteBonus[userLevel]; ok {
+		anteBonus := p.ante * multiplier
+		bonus += anteBonus
+		p.out <- utils.Green(utils.Bold("%s pays an ante bonus of %d to 1! (+%d chips)", PokerHandToString[userLevel], multiplier, anteBonus))
 	}
 
 	save := p.saveManager.Read()
 	save.RemainingChips += bonus
 	p.saveManager.Save(save)
 	p.userChips = save.RemainingChips
-
-	p.endGame()
 }
 
+// endGame clears the table once the hand is settled
 func (p *poker) endGame() {
 	for _, hand := range p.hands {
 		p.dealer.Discard(hand.Cards...)
 	}
 	p.hands = nil
+}
 
-	playAgainChoice := utils.GetInput(
+// playAgain asks whether the player wants another hand. Returns false if they
+// want to leave the table.
+func (p *poker) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
 		p.in,
 		p.out,
 		[]string{"yes", "y", "no", "n"},
 		"Play again? Yes (y) or no (n)",
 	)
+	if err != nil {
+		return false, err
+	}
+
 	switch playAgainChoice {
 	case "yes", "y":
-		p.Play()
-		return
+		return true, nil
 	default:
-		p.quit()
+		return false, nil
 	}
 }
 
@@ -240,6 +402,8 @@ func (p *poker) printUpdate() {
 	p.out <- utils.Dim("Your chips: %d", p.userChips)
 	p.out <- utils.Dim("Anted: %d", p.ante)
 	p.out <- utils.Dim("Pair Plus: %d", p.pairPlus)
+	p.out <- utils.Dim("6-Card Bonus: %d", p.sixCard)
+	p.out <- utils.Dim("Deck: %d cards to the cut card", p.dealer.Remaining())
 	p.out <- utils.Divider()
 
 	dealerHand := p.hands[entities.DealerRole]
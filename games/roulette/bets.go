@@ -0,0 +1,284 @@
+package roulette
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BetKind is where on the layout a bet is placed
+type BetKind int
+
+const (
+	Straight BetKind = iota
+	Split
+	Street
+	Corner
+	SixLine
+	Dozen
+	Column
+	Red
+	Black
+	Odd
+	Even
+	Low
+	High
+)
+
+// BetKindToPayout is what each kind of bet pays, X to 1
+var BetKindToPayout = map[BetKind]int{
+	Straight: 35,
+	Split:    17,
+	Street:   11,
+	Corner:   8,
+	SixLine:  5,
+	Dozen:    2,
+	Column:   2,
+	Red:      1,
+	Black:    1,
+	Odd:      1,
+	Even:     1,
+	Low:      1,
+	High:     1,
+}
+
+// outsideBets are the even money bets, which take a single word
+var outsideBets = map[string]BetKind{
+	"red":   Red,
+	"black": Black,
+	"odd":   Odd,
+	"even":  Even,
+	"low":   Low,
+	"1-18":  Low,
+	"high":  High,
+	"19-36": High,
+}
+
+// EvenMoney reports whether the bet pays 1 to 1, which la partage applies to
+func (k BetKind) EvenMoney() bool {
+	return BetKindToPayout[k] == 1
+}
+
+// Inside reports whether the bet is placed on the numbers themselves
+func (k BetKind) Inside() bool {
+	return k <= SixLine
+}
+
+// Bet is chips placed on the layout and the pockets they win on
+type Bet struct {
+	Kind    BetKind
+	Name    string
+	Pockets []Pocket
+	Amount  int
+}
+
+func (b Bet) Wins(pocket Pocket) bool {
+	return slices.Contains(b.Pockets, pocket)
+}
+
+// Winnings is how much the bet wins, not including the bet itself
+func (b Bet) Winnings() int {
+	return b.Amount * BetKindToPayout[b.Kind]
+}
+
+// BetHelp shows the player how to write bets
+var BetHelp = []string{
+	"17 25            25 on 17 (pays 35 to 1)",
+	"split 8-11 20    two numbers next to each other (17 to 1)",
+	"street 7 10      the row of three with 7 in it (11 to 1)",
+	"corner 8-12 10   the four numbers from 8 to 12 (8 to 1)",
+	"line 7-12 10     two rows of three (5 to 1)",
+	"dozen 2 50       13 to 24, column 1 50 for 1, 4, 7... (2 to 1)",
+	"red 100          or black, odd, even, low, high (1 to 1)",
+}
+
+// ParseBet reads a bet written as where it goes followed by how many chips,
+// e.g. "17 25", "red 100" or "split 8-11 20"
+func (t Table) ParseBet(command string) (Bet, error) {
+	fields := strings.Fields(strings.ToLower(command))
+	if len(fields) < 2 {
+		return Bet{}, fmt.Errorf("a bet needs a number of chips, e.g. \"red 100\"")
+	}
+	amount, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || amount <= 0 {
+		return Bet{}, fmt.Errorf("%q is not a number of chips", fields[len(fields)-1])
+	}
+
+	bet, err := t.parsePlacement(fields[:len(fields)-1])
+	bet.Amount = amount
+
+	return bet, err
+}
+
+// parsePlacement works out which pockets a bet covers from where it was placed
+func (t Table) parsePlacement(words []string) (Bet, error) {
+	if kind, ok := outsideBets[words[0]]; ok && len(words) == 1 {
+		return outsideBet(kind, words[0]), nil
+	}
+
+	if len(words) == 1 {
+		pocket, err := t.ParsePocket(words[0])
+		if err != nil {
+			return Bet{}, err
+		}
+		return Bet{Kind: Straight, Name: pocket.String(), Pockets: []Pocket{pocket}}, nil
+	}
+	if len(words) != 2 {
+		return Bet{}, fmt.Errorf("unknown bet %q", strings.Join(words, " "))
+	}
+
+	numbers, err := t.parseNumbers(words[1])
+	if err != nil {
+		return Bet{}, err
+	}
+
+	switch words[0] {
+	case "straight":
+		if len(numbers) != 1 {
+			return Bet{}, fmt.Errorf("a straight bet is on one number")
+		}
+		return Bet{Kind: Straight, Name: numbers[0].String(), Pockets: numbers}, nil
+	case "split":
+		if len(numbers) != 2 || !t.adjacent(numbers[0], numbers[1]) {
+			return Bet{}, fmt.Errorf("a split is on two numbers next to each other, e.g. 8-11")
+		}
+		return Bet{Kind: Split, Name: "split " + words[1], Pockets: numbers}, nil
+	case "street":
+		first, ok := rowStart(numbers, 3)
+		if !ok {
+			return Bet{}, fmt.Errorf("a street is a row of three, e.g. 7-9")
+		}
+		return rowBet(Street, "street", first, 3), nil
+	case "line":
+		first, ok := rowStart(numbers, 6)
+		if !ok || first > 31 {
+			return Bet{}, fmt.Errorf("a line is two rows of three, e.g. 7-12")
+		}
+		return rowBet(SixLine, "line", first, 6), nil
+	case "corner":
+		corner := numbers[0]
+		if len(numbers) > 2 || corner.IsZero() || corner%3 == 0 || corner > 32 || (len(numbers) == 2 && numbers[1] != corner+4) {
+			return Bet{}, fmt.Errorf("a corner is four numbers in a square, e.g. 8-12")
+		}
+		return Bet{
+			Kind:    Corner,
+			Name:    fmt.Sprintf("corner %d-%d", corner, corner+4),
+			Pockets: []Pocket{corner, corner + 1, corner + 3, corner + 4},
+		}, nil
+	case "dozen", "column":
+		n := numbers[0]
+		if len(numbers) != 1 || n < 1 || n > 3 {
+			return Bet{}, fmt.Errorf("there are three of each, pick 1, 2 or 3")
+		}
+		if words[0] == "dozen" {
+			return rowBet(Dozen, "dozen", (n-1)*12+1, 12), nil
+		}
+		bet := Bet{Kind: Column, Name: fmt.Sprintf("column %d", n)}
+		for p := n; p <= 36; p += 3 {
+			bet.Pockets = append(bet.Pockets, p)
+		}
+		return bet, nil
+	default:
+		return Bet{}, fmt.Errorf("unknown bet %q", strings.Join(words, " "))
+	}
+}
+
+// parseNumbers reads one pocket or several joined by dashes, e.g. "8-11"
+func (t Table) parseNumbers(s string) ([]Pocket, error) {
+	var pockets []Pocket
+	for _, part := range strings.Split(s, "-") {
+		pocket, err := t.ParsePocket(part)
+		if err != nil {
+			return nil, err
+		}
+		pockets = append(pockets, pocket)
+	}
+
+	return pockets, nil
+}
+
+// adjacent reports whether two pockets share an edge on the layout and can be
+// split. The zeros sit at the end of the layout next to 1, 2 and 3.
+func (t Table) adjacent(a, b Pocket) bool {
+	if a > b {
+		a, b = b, a
+	}
+
+	switch {
+	case a == b:
+		return false
+	case a == Zero && b == DoubleZero:
+		return true
+	case a == Zero && t.DoubleZero:
+		return b == 1 || b == 2
+	case a == Zero:
+		return b >= 1 && b <= 3
+	case b == DoubleZero:
+		return a == 2 || a == 3
+	}
+
+	// Across a row, or to the same place in the next row
+	sameRow := (a-1)/3 == (b-1)/3
+	return b-a == 3 || (b-a == 1 && sameRow)
+}
+
+// rowStart finds the first number of the row a street or line starts on. If a
+// range was given it has to cover exactly size numbers.
+func rowStart(numbers []Pocket, size Pocket) (Pocket, bool) {
+	if numbers[0].IsZero() {
+		return 0, false
+	}
+	first := (numbers[0]-1)/3*3 + 1
+	if len(numbers) == 2 && (numbers[0] != first || numbers[1] != first+size-1) {
+		return 0, false
+	}
+
+	return first, len(numbers) <= 2
+}
+
+// rowBet is a bet on count numbers in a row, starting at first
+func rowBet(kind BetKind, name string, first Pocket, count Pocket) Bet {
+	bet := Bet{Kind: kind}
+	for p := first; p < first+count; p++ {
+		bet.Pockets = append(bet.Pockets, p)
+	}
+
+	switch kind {
+	case Dozen:
+		bet.Name = fmt.Sprintf("dozen %d", first/12+1)
+	default:
+		bet.Name = fmt.Sprintf("%s %d-%d", name, first, first+count-1)
+	}
+
+	return bet
+}
+
+func outsideBet(kind BetKind, name string) Bet {
+	bet := Bet{Kind: kind, Name: name}
+	for p := Pocket(1); p <= 36; p++ {
+		if outsideWins(kind, p) {
+			bet.Pockets = append(bet.Pockets, p)
+		}
+	}
+
+	return bet
+}
+
+// outsideWins reports whether an even money bet wins on a number from 1 to 36
+func outsideWins(kind BetKind, p Pocket) bool {
+	switch kind {
+	case Red:
+		return p.IsRed()
+	case Black:
+		return p.IsBlack()
+	case Odd:
+		return p%2 == 1
+	case Even:
+		return p%2 == 0
+	case Low:
+		return p <= 18
+	default:
+		return p >= 19
+	}
+}
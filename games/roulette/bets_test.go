@@ -0,0 +1,131 @@
+package roulette
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	european = Table{Name: "European"}
+	american = Table{Name: "American", DoubleZero: true}
+)
+
+func TestParseBet(t *testing.T) {
+	tests := []struct {
+		table   Table
+		command string
+		want    Bet
+	}{
+		{european, "17 25", Bet{Straight, "17", []Pocket{17}, 25}},
+		{european, "0 5", Bet{Straight, "0", []Pocket{Zero}, 5}},
+		{american, "00 5", Bet{Straight, "00", []Pocket{DoubleZero}, 5}},
+		{european, "straight 36 1", Bet{Straight, "36", []Pocket{36}, 1}},
+		{european, "split 8-11 20", Bet{Split, "split 8-11", []Pocket{8, 11}, 20}},
+		{european, "split 5-4 20", Bet{Split, "split 5-4", []Pocket{5, 4}, 20}},
+		{european, "split 0-3 10", Bet{Split, "split 0-3", []Pocket{Zero, 3}, 10}},
+		{american, "split 0-1 10", Bet{Split, "split 0-1", []Pocket{Zero, 1}, 10}},
+		{american, "split 0-2 10", Bet{Split, "split 0-2", []Pocket{Zero, 2}, 10}},
+		{american, "split 00-2 10", Bet{Split, "split 00-2", []Pocket{DoubleZero, 2}, 10}},
+		{american, "split 00-3 10", Bet{Split, "split 00-3", []Pocket{DoubleZero, 3}, 10}},
+		{american, "split 0-00 10", Bet{Split, "split 0-00", []Pocket{Zero, DoubleZero}, 10}},
+		{european, "street 7 10", Bet{Street, "street 7-9", []Pocket{7, 8, 9}, 10}},
+		{european, "street 8 10", Bet{Street, "street 7-9", []Pocket{7, 8, 9}, 10}},
+		{european, "street 34-36 10", Bet{Street, "street 34-36", []Pocket{34, 35, 36}, 10}},
+		{european, "corner 8-12 10", Bet{Corner, "corner 8-12", []Pocket{8, 9, 11, 12}, 10}},
+		{european, "corner 32 10", Bet{Corner, "corner 32-36", []Pocket{32, 33, 35, 36}, 10}},
+		{european, "line 7-12 10", Bet{SixLine, "line 7-12", []Pocket{7, 8, 9, 10, 11, 12}, 10}},
+		{european, "line 31 10", Bet{SixLine, "line 31-36", []Pocket{31, 32, 33, 34, 35, 36}, 10}},
+		{european, "dozen 2 50", Bet{Dozen, "dozen 2", []Pocket{13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}, 50}},
+		{european, "column 1 50", Bet{Column, "column 1", []Pocket{1, 4, 7, 10, 13, 16, 19, 22, 25, 28, 31, 34}, 50}},
+		{european, "Red 100", Bet{Red, "red", redPockets, 100}},
+		{european, "odd 100", Bet{Odd, "odd", []Pocket{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 33, 35}, 100}},
+		{european, "1-18 100", Bet{Low, "1-18", []Pocket{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18}, 100}},
+		{european, "high 100", Bet{High, "high", []Pocket{19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36}, 100}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table.Name+" "+test.command, func(t *testing.T) {
+			bet, err := test.table.ParseBet(test.command)
+			if err != nil {
+				t.Fatalf("ParseBet(%q) failed: %s", test.command, err)
+			}
+			if !reflect.DeepEqual(bet, test.want) {
+				t.Errorf("ParseBet(%q) = %+v, want %+v", test.command, bet, test.want)
+			}
+		})
+	}
+}
+
+func TestParseBetRejects(t *testing.T) {
+	tests := []struct {
+		table   Table
+		command string
+	}{
+		{european, "red"},
+		{european, "red 0"},
+		{european, "red lots"},
+		{european, "37 5"},
+		{european, "00 5"},
+		{european, "split 3-4 5"},
+		{european, "split 1-5 5"},
+		{european, "split 8 5"},
+		{american, "split 0-3 5"},
+		{american, "split 00-1 5"},
+		{european, "street 0 5"},
+		{european, "street 7-10 5"},
+		{european, "line 34 5"},
+		{european, "line 7-9 5"},
+		{european, "corner 3 5"},
+		{european, "corner 33 5"},
+		{european, "corner 0 5"},
+		{european, "corner 8-11 5"},
+		{european, "dozen 4 5"},
+		{european, "column 0 5"},
+		{european, "red black 5"},
+		{european, "basket 0-3 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table.Name+" "+test.command, func(t *testing.T) {
+			if bet, err := test.table.ParseBet(test.command); err == nil {
+				t.Errorf("ParseBet(%q) = %+v, want an error", test.command, bet)
+			}
+		})
+	}
+}
+
+func TestAdjacent(t *testing.T) {
+	tests := []struct {
+		table    Table
+		a, b     Pocket
+		adjacent bool
+	}{
+		{european, Zero, 1, true},
+		{european, Zero, 2, true},
+		{european, Zero, 3, true},
+		{european, Zero, 4, false},
+		{american, Zero, 1, true},
+		{american, Zero, 2, true},
+		{american, Zero, 3, false},
+		{american, DoubleZero, 1, false},
+		{american, DoubleZero, 2, true},
+		{american, DoubleZero, 3, true},
+		{american, Zero, DoubleZero, true},
+		{american, DoubleZero, 36, false},
+		{european, 1, 2, true},
+		{european, 3, 4, false},
+		{european, 3, 6, true},
+		{european, 33, 36, true},
+		{european, 1, 5, false},
+		{european, 17, 17, false},
+	}
+
+	for _, test := range tests {
+		if got := test.table.adjacent(test.a, test.b); got != test.adjacent {
+			t.Errorf("%s adjacent(%s, %s) = %t, want %t", test.table.Name, test.a, test.b, got, test.adjacent)
+		}
+		if got := test.table.adjacent(test.b, test.a); got != test.adjacent {
+			t.Errorf("%s adjacent(%s, %s) = %t, want %t", test.table.Name, test.b, test.a, got, test.adjacent)
+		}
+	}
+}
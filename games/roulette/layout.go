@@ -0,0 +1,113 @@
+package roulette
+
+import (
+	"fmt"
+	"strings"
+
+	"casino/utils"
+)
+
+const (
+	// layoutColumns is how many columns of three numbers the layout has
+	layoutColumns = 12
+	// cellWidth is how wide each number is drawn, not counting borders
+	cellWidth = 4
+	// zeroWidth is how wide the zeros at the end of the layout are drawn
+	zeroWidth = 5
+)
+
+// noPocket is passed to RenderLayout when the ball hasn't landed yet
+const noPocket Pocket = -1
+
+// RenderLayout draws the betting layout. Numbers with inside bets on them are
+// marked with a dot, and the pocket the ball landed on, if any, with a star.
+func (t Table) RenderLayout(covered map[Pocket]bool, landed Pocket) []string {
+	border := func(left, middle, right string) string {
+		return strings.Repeat(" ", zeroWidth) + left +
+			strings.Repeat(strings.Repeat("─", cellWidth)+middle, layoutColumns-1) +
+			strings.Repeat("─", cellWidth) + right
+	}
+
+	var lines []string
+	lines = append(lines, border("┌", "┬", "┐"))
+	for row := range 3 {
+		if row > 0 {
+			lines = append(lines, border("├", "┼", "┤"))
+		}
+
+		// The top row is 3, 6, 9... and the bottom row 1, 4, 7...
+		cells := make([]string, layoutColumns)
+		for column := range layoutColumns {
+			p := Pocket(column*3 + 3 - row)
+			cells[column] = cell(p, covered[p], p == landed)
+		}
+		lines = append(lines, t.zeroCell(row, covered, landed)+"│"+strings.Join(cells, "│")+"│ 2to1")
+	}
+	lines = append(lines, border("└", "┴", "┘"))
+
+	dozenWidth := layoutColumns/3*(cellWidth+1) - 1
+	var dozens []string
+	for _, label := range []string{"1st 12", "2nd 12", "3rd 12"} {
+		dozens = append(dozens, utils.PadCenter(label, dozenWidth))
+	}
+	lines = append(lines, strings.Repeat(" ", zeroWidth+1)+strings.Join(dozens, "│"))
+
+	outsideWidth := layoutColumns/6*(cellWidth+1) - 1
+	var outside []string
+	for _, label := range []string{"1-18", "EVEN", utils.Red("%s", utils.PadCenter("RED", outsideWidth)), "BLACK", "ODD", "19-36"} {
+		if utils.VisibleWidth(label) < outsideWidth {
+			label = utils.PadCenter(label, outsideWidth)
+		}
+		outside = append(outside, label)
+	}
+	lines = append(lines, strings.Repeat(" ", zeroWidth+1)+strings.Join(outside, "│"))
+
+	return lines
+}
+
+// zeroCell is the part of a row of the layout taken up by the zeros. A single
+// zero sits in the middle row. A double zero wheel has 00 at the top, next to
+// 3, and 0 at the bottom, next to 1.
+func (t Table) zeroCell(row int, covered map[Pocket]bool, landed Pocket) string {
+	p := noPocket
+	switch {
+	case t.DoubleZero && row == 0:
+		p = DoubleZero
+	case t.DoubleZero && row == 2:
+		p = Zero
+	case !t.DoubleZero && row == 1:
+		p = Zero
+	}
+
+	if p == noPocket {
+		return strings.Repeat(" ", zeroWidth)
+	}
+
+	return strings.Repeat(" ", zeroWidth-cellWidth) + cell(p, covered[p], p == landed)
+}
+
+// cell draws one number in its colour, marked if it has a bet on it or the ball
+// landed in it
+func cell(p Pocket, covered, landed bool) string {
+	mark := " "
+	switch {
+	case landed:
+		mark = utils.Yellow(utils.Bold("★"))
+	case covered:
+		mark = utils.Cyan("●")
+	}
+
+	return colorPocket(p, fmt.Sprintf("%*s", cellWidth-1, p)) + mark
+}
+
+// colorPocket draws text in the colour of a pocket
+func colorPocket(p Pocket, text string) string {
+	switch {
+	case p.IsZero():
+		return utils.Green("%s", text)
+	case p.IsRed():
+		return utils.Red("%s", text)
+	default:
+		return utils.Bold("%s", text)
+	}
+}
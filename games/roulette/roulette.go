@@ -0,0 +1,304 @@
+package roulette
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"casino/games"
+	"casino/utils"
+)
+
+// historyLength is how many of the latest spins are shown
+const historyLength = 15
+
+type roulette struct {
+	saveManager utils.SaveDataManager
+	table       Table
+	tableChosen bool
+
+	bets      []Bet
+	lastBets  []Bet    // the bets on the last spin, for rebetting
+	history   []Pocket // the latest spins, oldest first
+	userChips int
+	messages  []string // feedback on the last command, shown under the layout
+
+	in  chan string
+	out chan string
+}
+
+func NewRoulette(
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	r := &roulette{
+		saveManager: saveManager,
+		table:       Tables[0],
+		in:          in,
+		out:         out,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Option configures a roulette game
+type Option func(*roulette)
+
+// WithTable sets the wheel and rules. The player won't be asked to pick a table.
+func WithTable(table Table) Option {
+	return func(r *roulette) {
+		r.table = table
+		r.tableChosen = true
+	}
+}
+
+func (r *roulette) Name() string {
+	return "Roulette"
+}
+
+// Play runs spins until the player chooses to leave the table. Every spin
+// returns here before the next one starts.
+func (r *roulette) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := r.saveManager.Read().RemainingChips
+
+	for {
+		played, err := r.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = r.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = r.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the player's bets and spins the wheel. Returns whether the
+// wheel was spun. No chips change hands until the spin, so leaving while
+// betting costs nothing.
+func (r *roulette) playRound(ctx context.Context) (bool, error) {
+	utils.Clear(r.out)
+	r.userChips = r.saveManager.Read().RemainingChips
+
+	utils.PrintBanner(r.Name(), r.out)
+	if !r.tableChosen {
+		if err := r.chooseTable(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.placeBets(ctx); err != nil {
+		return false, err
+	}
+	r.spin()
+
+	return true, nil
+}
+
+// chooseTable asks the player which wheel they want to play
+func (r *roulette) chooseTable(ctx context.Context) error {
+	r.out <- "Pick a table:"
+	var choices []string
+	for i, table := range Tables {
+		choice := fmt.Sprint(i + 1)
+		choices = append(choices, choice)
+		r.out <- fmt.Sprintf("%s. %s", choice, table.Name)
+	}
+
+	choice, err := utils.GetInput(ctx, r.in, r.out, choices, "Which table? ("+strings.Join(choices, ", ")+")")
+	if err != nil {
+		return err
+	}
+	r.table = Tables[slices.Index(choices, choice)]
+	r.tableChosen = true
+
+	return nil
+}
+
+// placeBets reads bets until the player spins the wheel
+func (r *roulette) placeBets(ctx context.Context) error {
+	r.bets = nil
+	r.messages = []string{utils.Dim("Type help to see how to bet")}
+
+	for {
+		r.printTable(noPocket)
+		r.out <- "Place a bet (e.g. 17 25, red 100, split 8-11 20), or spin (s), undo, clear, rebet or help"
+		line, err := utils.ReadLine(ctx, r.in)
+		if err != nil {
+			return err
+		}
+
+		r.messages = nil
+		switch command := strings.ToLower(strings.TrimSpace(line)); command {
+		case "spin", "s":
+			if len(r.bets) > 0 {
+				return nil
+			}
+			r.messages = append(r.messages, utils.Yellow("Place a bet first"))
+		case "undo":
+			if len(r.bets) > 0 {
+				r.bets = r.bets[:len(r.bets)-1]
+			}
+		case "clear":
+			r.bets = nil
+		case "rebet":
+			switch {
+			case len(r.lastBets) == 0:
+				r.messages = append(r.messages, utils.Yellow("There's no last spin to rebet"))
+			case totalBets(r.lastBets) > r.userChips:
+				r.messages = append(r.messages, utils.Yellow("You don't have enough chips to rebet %d", totalBets(r.lastBets)))
+			default:
+				r.bets = slices.Clone(r.lastBets)
+			}
+		case "help":
+			r.messages = append(r.messages, BetHelp...)
+		default:
+			r.addBet(command)
+		}
+	}
+}
+
+// addBet places a bet written by the player, if it's valid and they can cover it
+func (r *roulette) addBet(command string) {
+	bet, err := r.table.ParseBet(command)
+	if err != nil {
+		r.messages = append(r.messages, utils.Red("%s", err.Error()))
+		return
+	}
+
+	if left := r.userChips - totalBets(r.bets); bet.Amount > left {
+		r.messages = append(r.messages, utils.Yellow("You only have %d chips left to bet", left))
+		return
+	}
+
+	r.bets = append(r.bets, bet)
+	r.messages = append(r.messages, utils.Green("%d on %s", bet.Amount, bet.Name))
+}
+
+// spin takes the bets, spins the wheel and pays out
+func (r *roulette) spin() {
+	total := totalBets(r.bets)
+	save := r.saveManager.Read()
+	save.RemainingChips -= total
+	r.saveManager.Save(save)
+	r.lastBets = r.bets
+
+	landed := r.table.Spin()
+	r.history = append(r.history, landed)
+	r.history = r.history[max(0, len(r.history)-historyLength):]
+
+	r.messages = nil
+	r.printTable(landed)
+	r.out <- utils.Bold("The ball lands on %s", colorPocket(landed, fmt.Sprintf("%s %s", landed, landed.ColorName())))
+
+	returned := 0
+	for _, bet := range r.bets {
+		switch {
+		case bet.Wins(landed):
+			returned += bet.Amount + bet.Winnings()
+			r.out <- utils.Green("%s pays %d to 1 (+%d chips)", bet.Name, BetKindToPayout[bet.Kind], bet.Winnings())
+		case landed.IsZero() && r.table.LaPartage && bet.Kind.EvenMoney():
+			half := bet.Amount / 2
+			returned += half
+			r.out <- utils.Yellow("La partage: half your %s bet back (-%d chips)", bet.Name, bet.Amount-half)
+		default:
+			r.out <- utils.Red("%s loses (-%d chips)", bet.Name, bet.Amount)
+		}
+	}
+
+	save = r.saveManager.Read()
+	save.RemainingChips += returned
+	r.saveManager.Save(save)
+	r.userChips = save.RemainingChips
+
+	switch net := returned - total; {
+	case net > 0:
+		r.out <- utils.Green(utils.Bold("You're up %d chips this spin", net))
+	case net < 0:
+		r.out <- utils.Red(utils.Bold("You're down %d chips this spin", -net))
+	default:
+		r.out <- "You broke even this spin"
+	}
+	r.bets = nil
+}
+
+// playAgain asks whether the player wants another spin. Returns false if they
+// want to leave the table.
+func (r *roulette) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		r.in,
+		r.out,
+		[]string{"yes", "y", "no", "n"},
+		"Spin again? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// printTable shows the layout with the bets on it, the latest spins and any
+// messages for the player
+func (r *roulette) printTable(landed Pocket) {
+	utils.Clear(r.out)
+
+	onTable := totalBets(r.bets)
+	r.out <- utils.Dim("Your chips: %d (%d on the table)", r.userChips-onTable, onTable)
+	r.out <- utils.Dim("Table: %s", r.table.Name)
+	if len(r.history) > 0 {
+		var spins []string
+		for _, p := range r.history {
+			spins = append(spins, colorPocket(p, p.String()))
+		}
+		r.out <- utils.Dim("Last spins: ") + strings.Join(spins, " ")
+	}
+	r.out <- utils.Divider()
+
+	covered := map[Pocket]bool{}
+	for _, bet := range r.bets {
+		if bet.Kind.Inside() {
+			for _, p := range bet.Pockets {
+				covered[p] = true
+			}
+		}
+	}
+	for _, line := range r.table.RenderLayout(covered, landed) {
+		r.out <- line
+	}
+	r.out <- utils.Divider()
+
+	for _, bet := range r.bets {
+		r.out <- fmt.Sprintf("%s %d", utils.PadRight(bet.Name, 16), bet.Amount)
+	}
+	for _, message := range r.messages {
+		r.out <- message
+	}
+}
+
+func totalBets(bets []Bet) int {
+	total := 0
+	for _, bet := range bets {
+		total += bet.Amount
+	}
+
+	return total
+}
@@ -0,0 +1,103 @@
+package roulette
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+)
+
+// Pocket is a number on the wheel. The American double zero is DoubleZero.
+type Pocket int
+
+const (
+	Zero       Pocket = 0
+	DoubleZero Pocket = 37
+)
+
+var redPockets = []Pocket{1, 3, 5, 7, 9, 12, 14, 16, 18, 19, 21, 23, 25, 27, 30, 32, 34, 36}
+
+func (p Pocket) String() string {
+	if p == DoubleZero {
+		return "00"
+	}
+
+	return strconv.Itoa(int(p))
+}
+
+// IsZero reports whether the pocket is a green zero
+func (p Pocket) IsZero() bool {
+	return p == Zero || p == DoubleZero
+}
+
+func (p Pocket) IsRed() bool {
+	return slices.Contains(redPockets, p)
+}
+
+func (p Pocket) IsBlack() bool {
+	return !p.IsZero() && !p.IsRed()
+}
+
+// ColorName is "red", "black" or "green"
+func (p Pocket) ColorName() string {
+	switch {
+	case p.IsZero():
+		return "green"
+	case p.IsRed():
+		return "red"
+	default:
+		return "black"
+	}
+}
+
+// Table is a roulette wheel and the rules played with it
+type Table struct {
+	Name string
+	// DoubleZero adds the 00 pocket of the American wheel
+	DoubleZero bool
+	// LaPartage gives back half of even money bets when the ball lands on zero
+	LaPartage bool
+}
+
+// Tables are the tables offered to the player
+var Tables = []Table{
+	{Name: "European (single zero)"},
+	{Name: "French (single zero, la partage)", LaPartage: true},
+	{Name: "American (double zero)", DoubleZero: true},
+}
+
+// Pockets lists every pocket on the table's wheel
+func (t Table) Pockets() []Pocket {
+	var pockets []Pocket
+	for p := Zero; p <= 36; p++ {
+		pockets = append(pockets, p)
+	}
+	if t.DoubleZero {
+		pockets = append(pockets, DoubleZero)
+	}
+
+	return pockets
+}
+
+// Spin sends the ball round the wheel and returns where it lands
+func (t Table) Spin() Pocket {
+	pockets := t.Pockets()
+	return pockets[rand.Intn(len(pockets))]
+}
+
+// ParsePocket reads a pocket number, "0" to "36", or "00" on an American wheel
+func (t Table) ParsePocket(s string) (Pocket, error) {
+	if s == "00" {
+		if !t.DoubleZero {
+			return 0, fmt.Errorf("there's no 00 on a single zero wheel")
+		}
+		return DoubleZero, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 36 {
+		return 0, fmt.Errorf("%q is not a number on the wheel", s)
+	}
+
+	return Pocket(n), nil
+}
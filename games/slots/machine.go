@@ -0,0 +1,256 @@
+package slots
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Rows is how many symbols of each reel are shown
+const Rows = 3
+
+// Symbol is a picture on the reels and what a line of them pays
+type Symbol struct {
+	Name string `json:"name"`
+	// Color is how the symbol is drawn: red, green, yellow, blue, cyan or bold
+	Color string `json:"color"`
+	// Pays maps how many of the symbol are in a row, from the left, to how many
+	// times the line bet they win. Scatter pays are times the total bet instead.
+	Pays map[int]int `json:"pays"`
+}
+
+// Reel is a reel strip, top to bottom. Each stop has a weight, which is how
+// likely the reel is to stop there compared to the other stops. Without weights
+// every stop is as likely as any other.
+type Reel struct {
+	Strip   []string `json:"strip"`
+	Weights []int    `json:"weights,omitempty"`
+}
+
+// FreeSpins is the feature landed by enough scatters
+type FreeSpins struct {
+	// Scatters is how many scatters anywhere on the reels trigger the feature. 0
+	// turns free spins off.
+	Scatters int `json:"scatters"`
+	Spins    int `json:"spins"`
+	// Multiplier multiplies everything won during free spins
+	Multiplier int `json:"multiplier"`
+}
+
+// Machine describes a slot machine: its reels, paylines, and what pays
+type Machine struct {
+	Name    string   `json:"name"`
+	Symbols []Symbol `json:"symbols"`
+	Reels   []Reel   `json:"reels"`
+	// Paylines are the rows, from 0 at the top, each line crosses on every reel
+	Paylines [][]int `json:"paylines"`
+	// Wild substitutes for every symbol except the scatter. It's optional.
+	Wild string `json:"wild,omitempty"`
+	// Scatter pays wherever it lands, and can trigger free spins. It's optional.
+	Scatter    string    `json:"scatter,omitempty"`
+	FreeSpins  FreeSpins `json:"freeSpins"`
+	MaxLineBet int       `json:"maxLineBet"`
+}
+
+// LoadMachine reads a machine definition from a JSON file
+func LoadMachine(path string) (Machine, error) {
+	var machine Machine
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return machine, err
+	}
+	if err := json.Unmarshal(bytes, &machine); err != nil {
+		return machine, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return machine, machine.Validate()
+}
+
+// Validate checks that the machine can be played
+func (m Machine) Validate() error {
+	_, err := newEngine(m)
+	return err
+}
+
+// symbolColors are the colors a symbol can be drawn in
+var symbolColors = []string{"", "red", "green", "yellow", "blue", "cyan", "bold"}
+
+// engine is a machine with its symbols looked up, ready to spin and pay
+type engine struct {
+	machine Machine
+	// reels are the symbols on each reel strip, by index into machine.Symbols
+	reels [][]int
+	// weights are the weights of each reel's stops and totals their sums
+	weights [][]int
+	totals  []int
+	// pays are what each symbol pays for each count, from 0 to the number of reels
+	pays [][]int
+	// wild and scatter are symbol indexes, or noSymbol if the machine has none
+	wild    int
+	scatter int
+}
+
+// noSymbol stands in for a wild or scatter the machine doesn't have
+const noSymbol = -1
+
+func newEngine(m Machine) (*engine, error) {
+	e := &engine{machine: m, wild: noSymbol, scatter: noSymbol}
+
+	if len(m.Symbols) == 0 {
+		return nil, fmt.Errorf("the machine needs symbols")
+	}
+	if len(m.Reels) < 3 || len(m.Reels) > 5 {
+		return nil, fmt.Errorf("the machine needs 3 to 5 reels, got %d", len(m.Reels))
+	}
+	if len(m.Paylines) == 0 {
+		return nil, fmt.Errorf("the machine needs paylines")
+	}
+	if m.MaxLineBet < 1 {
+		return nil, fmt.Errorf("maxLineBet must be at least 1, got %d", m.MaxLineBet)
+	}
+
+	index := map[string]int{}
+	for i, symbol := range m.Symbols {
+		if _, ok := index[symbol.Name]; ok || symbol.Name == "" {
+			return nil, fmt.Errorf("symbol names must be unique and not empty, got %q", symbol.Name)
+		}
+		if !slices.Contains(symbolColors, symbol.Color) {
+			return nil, fmt.Errorf("symbol %s has unknown color %q", symbol.Name, symbol.Color)
+		}
+		index[symbol.Name] = i
+
+		pays := make([]int, len(m.Reels)+1)
+		for count, pay := range symbol.Pays {
+			if count < 1 || count > len(m.Reels) || pay < 0 {
+				return nil, fmt.Errorf("symbol %s can't pay %d for %d", symbol.Name, pay, count)
+			}
+			pays[count] = pay
+		}
+		e.pays = append(e.pays, pays)
+	}
+
+	lookup := func(name, role string) (int, error) {
+		if name == "" {
+			return noSymbol, nil
+		}
+		i, ok := index[name]
+		if !ok {
+			return noSymbol, fmt.Errorf("the %s %q isn't one of the symbols", role, name)
+		}
+		return i, nil
+	}
+	var err error
+	if e.wild, err = lookup(m.Wild, "wild"); err != nil {
+		return nil, err
+	}
+	if e.scatter, err = lookup(m.Scatter, "scatter"); err != nil {
+		return nil, err
+	}
+	if e.wild != noSymbol && e.wild == e.scatter {
+		return nil, fmt.Errorf("the wild and the scatter must be different symbols")
+	}
+
+	for i, reel := range m.Reels {
+		if len(reel.Strip) < Rows {
+			return nil, fmt.Errorf("reel %d needs at least %d stops", i+1, Rows)
+		}
+		if reel.Weights != nil && len(reel.Weights) != len(reel.Strip) {
+			return nil, fmt.Errorf("reel %d has %d stops but %d weights", i+1, len(reel.Strip), len(reel.Weights))
+		}
+
+		var symbols, weights []int
+		total := 0
+		for stop, name := range reel.Strip {
+			symbol, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("reel %d has unknown symbol %q", i+1, name)
+			}
+			weight := 1
+			if reel.Weights != nil {
+				weight = reel.Weights[stop]
+			}
+			if weight < 1 {
+				return nil, fmt.Errorf("reel %d stop %d must have a weight of at least 1", i+1, stop+1)
+			}
+			symbols = append(symbols, symbol)
+			weights = append(weights, weight)
+			total += weight
+		}
+		e.reels = append(e.reels, symbols)
+		e.weights = append(e.weights, weights)
+		e.totals = append(e.totals, total)
+	}
+
+	for i, line := range m.Paylines {
+		if len(line) != len(m.Reels) {
+			return nil, fmt.Errorf("payline %d crosses %d reels, the machine has %d", i+1, len(line), len(m.Reels))
+		}
+		for _, row := range line {
+			if row < 0 || row >= Rows {
+				return nil, fmt.Errorf("payline %d uses row %d, rows go from 0 to %d", i+1, row, Rows-1)
+			}
+		}
+	}
+
+	if fs := m.FreeSpins; fs.Scatters > 0 {
+		switch {
+		case e.scatter == noSymbol:
+			return nil, fmt.Errorf("free spins need a scatter symbol")
+		case fs.Scatters > len(m.Reels)*Rows:
+			return nil, fmt.Errorf("free spins can't need more scatters than there are symbols showing")
+		case fs.Spins < 1 || fs.Multiplier < 1:
+			return nil, fmt.Errorf("free spins need at least 1 spin and a multiplier of at least 1")
+		}
+	}
+
+	return e, nil
+}
+
+// DefaultMachine is the machine played when none is configured
+func DefaultMachine() Machine {
+	return Machine{
+		Name: "Lucky Sevens",
+		Symbols: []Symbol{
+			{Name: "CHERRY", Color: "red", Pays: map[int]int{3: 4, 4: 10, 5: 40}},
+			{Name: "LEMON", Color: "yellow", Pays: map[int]int{3: 4, 4: 10, 5: 40}},
+			{Name: "PLUM", Color: "blue", Pays: map[int]int{3: 6, 4: 20, 5: 75}},
+			{Name: "BELL", Color: "yellow", Pays: map[int]int{3: 12, 4: 40, 5: 150}},
+			{Name: "BAR", Color: "bold", Pays: map[int]int{3: 25, 4: 75, 5: 250}},
+			{Name: "SEVEN", Color: "red", Pays: map[int]int{3: 50, 4: 200, 5: 1000}},
+			{Name: "WILD", Color: "green", Pays: map[int]int{3: 100, 4: 500, 5: 2500}},
+			{Name: "STAR", Color: "cyan", Pays: map[int]int{3: 2, 4: 10, 5: 50}},
+		},
+		Reels: []Reel{
+			{Strip: defaultStrip},
+			{Strip: defaultStrip},
+			{Strip: defaultStrip},
+			{Strip: defaultStrip},
+			{Strip: defaultStrip},
+		},
+		Paylines: [][]int{
+			{1, 1, 1, 1, 1},
+			{0, 0, 0, 0, 0},
+			{2, 2, 2, 2, 2},
+			{0, 1, 2, 1, 0},
+			{2, 1, 0, 1, 2},
+			{0, 0, 1, 2, 2},
+			{2, 2, 1, 0, 0},
+			{1, 0, 0, 0, 1},
+			{1, 2, 2, 2, 1},
+			{1, 0, 1, 2, 1},
+		},
+		Wild:       "WILD",
+		Scatter:    "STAR",
+		FreeSpins:  FreeSpins{Scatters: 3, Spins: 8, Multiplier: 2},
+		MaxLineBet: 5,
+	}
+}
+
+// defaultStrip is the reel strip every reel of the default machine uses
+var defaultStrip = []string{
+	"CHERRY", "LEMON", "PLUM", "CHERRY", "BELL", "LEMON", "BAR", "CHERRY",
+	"STAR", "LEMON", "PLUM", "CHERRY", "SEVEN", "LEMON", "BELL", "PLUM",
+	"CHERRY", "WILD", "LEMON", "BAR", "PLUM", "CHERRY", "LEMON", "BELL",
+}
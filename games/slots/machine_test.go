@@ -0,0 +1,58 @@
+package slots
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewEngineValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(m *Machine)
+		err    string
+	}{
+		{"no symbols", func(m *Machine) { m.Symbols = nil }, "needs symbols"},
+		{"too few reels", func(m *Machine) { m.Reels = m.Reels[:2] }, "3 to 5 reels"},
+		{"no paylines", func(m *Machine) { m.Paylines = nil }, "needs paylines"},
+		{"no line bet", func(m *Machine) { m.MaxLineBet = 0 }, "maxLineBet"},
+		{"duplicate symbol", func(m *Machine) { m.Symbols[1].Name = "A" }, "unique"},
+		{"unnamed symbol", func(m *Machine) { m.Symbols[1].Name = "" }, "unique"},
+		{"unknown color", func(m *Machine) { m.Symbols[0].Color = "purple" }, "unknown color"},
+		{"pays for more symbols than reels", func(m *Machine) { m.Symbols[0].Pays = map[int]int{4: 10} }, "can't pay"},
+		{"negative pay", func(m *Machine) { m.Symbols[0].Pays = map[int]int{3: -1} }, "can't pay"},
+		{"unknown wild", func(m *Machine) { m.Wild = "JOKER" }, "wild"},
+		{"unknown scatter", func(m *Machine) { m.Scatter = "STAR" }, "scatter"},
+		{"wild is the scatter", func(m *Machine) { m.Wild = "S" }, "different symbols"},
+		{"short reel", func(m *Machine) { m.Reels[1].Strip = []string{"A", "B"} }, "at least 3 stops"},
+		{"weights don't match stops", func(m *Machine) { m.Reels[0].Weights = []int{1, 2} }, "but 2 weights"},
+		{"zero weight", func(m *Machine) { m.Reels[2].Weights = []int{1, 0, 1, 1} }, "weight of at least 1"},
+		{"unknown reel symbol", func(m *Machine) { m.Reels[0].Strip = []string{"A", "B", "W", "X"} }, "unknown symbol"},
+		{"payline too short", func(m *Machine) { m.Paylines = [][]int{{1, 1}} }, "crosses 2 reels"},
+		{"payline off the window", func(m *Machine) { m.Paylines = [][]int{{1, 3, 1}} }, "row 3"},
+		{"free spins without a scatter", func(m *Machine) { m.Scatter = "" }, "need a scatter"},
+		{"free spins need too many scatters", func(m *Machine) { m.FreeSpins.Scatters = 10 }, "more scatters"},
+		{"no free spins", func(m *Machine) { m.FreeSpins.Spins = 0 }, "at least 1 spin"},
+		{"no free spin multiplier", func(m *Machine) { m.FreeSpins.Multiplier = 0 }, "at least 1 spin"},
+	}
+
+	if _, err := newEngine(tinyMachine()); err != nil {
+		t.Fatalf("tiny machine doesn't validate: %s", err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			machine := tinyMachine()
+			test.change(&machine)
+
+			_, err := newEngine(machine)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("newEngine error = %v, want one about %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestDefaultMachineValidates(t *testing.T) {
+	if err := DefaultMachine().Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,137 @@
+package slots
+
+import (
+	"fmt"
+	"strings"
+
+	"casino/utils"
+)
+
+// cellWidth is how wide each symbol is drawn on the grid, not counting borders
+const cellWidth = 8
+
+// symbolColor draws text in a symbol's color
+var symbolColor = map[string]func(string, ...any) string{
+	"":       fmt.Sprintf,
+	"red":    utils.Red,
+	"green":  utils.Green,
+	"yellow": utils.Yellow,
+	"blue":   utils.Blue,
+	"cyan":   utils.Cyan,
+	"bold":   utils.Bold,
+}
+
+// renderGrid draws the symbols showing on the reels. Symbols on a winning line
+// are marked with stars.
+func (e *engine) renderGrid(grid Grid, winning map[[2]int]bool) []string {
+	border := func(left, middle, right string) string {
+		return left + strings.Repeat(strings.Repeat("─", cellWidth)+middle, len(grid)-1) +
+			strings.Repeat("─", cellWidth) + right
+	}
+
+	lines := []string{border("┌", "┬", "┐")}
+	for row := range Rows {
+		var cells []string
+		for reel, column := range grid {
+			symbol := e.machine.Symbols[column[row]]
+			name := symbol.Name
+			if winning[[2]int{reel, row}] {
+				name = "*" + name + "*"
+			}
+			cells = append(cells, symbolColor[symbol.Color]("%s", utils.PadCenter(name, cellWidth)))
+		}
+		lines = append(lines, "│"+strings.Join(cells, "│")+"│")
+	}
+	lines = append(lines, border("└", "┴", "┘"))
+
+	return lines
+}
+
+// renderPaytable lists what each symbol pays, best symbol first, with the
+// scatter last
+func (e *engine) renderPaytable() []string {
+	header := utils.PadRight("Pays", 12)
+	for count := len(e.reels); count >= e.fewestPaid(); count-- {
+		header += fmt.Sprintf("%7s", fmt.Sprintf("%dx", count))
+	}
+	lines := []string{utils.Bold("%s", header)}
+
+	for i := len(e.machine.Symbols) - 1; i >= 0; i-- {
+		if i == e.scatter {
+			continue
+		}
+		lines = append(lines, e.paytableRow(i, ""))
+	}
+	if e.scatter != noSymbol {
+		lines = append(lines, e.paytableRow(e.scatter, "times the total bet, anywhere"))
+	}
+	if e.wild != noSymbol {
+		lines = append(lines, utils.Dim("%s stands in for every symbol but %s", e.machine.Wild, e.machine.Scatter))
+	}
+	if fs := e.machine.FreeSpins; fs.Scatters > 0 {
+		lines = append(lines, utils.Dim("%d or more %s win %d free spins, paying %dx", fs.Scatters, e.machine.Scatter, fs.Spins, fs.Multiplier))
+	}
+
+	return lines
+}
+
+// fewestPaid is the fewest of a symbol any symbol pays for
+func (e *engine) fewestPaid() int {
+	fewest := len(e.reels)
+	for _, pays := range e.pays {
+		for count, pay := range pays {
+			if pay > 0 {
+				fewest = min(fewest, count)
+			}
+		}
+	}
+
+	return fewest
+}
+
+// paytableRow is what one symbol pays for each count, most first
+func (e *engine) paytableRow(symbol int, note string) string {
+	s := e.machine.Symbols[symbol]
+	row := symbolColor[s.Color]("%s", utils.PadRight(s.Name, 12))
+	for count := len(e.reels); count >= e.fewestPaid(); count-- {
+		pays := "-"
+		if e.pays[symbol][count] > 0 {
+			pays = fmt.Sprint(e.pays[symbol][count])
+		}
+		row += fmt.Sprintf("%7s", pays)
+	}
+	if note != "" {
+		row += utils.Dim(" %s", note)
+	}
+
+	return row
+}
+
+// renderPaylines draws each payline as a small grid, numbered from 1
+func (e *engine) renderPaylines() []string {
+	// Enough paylines to fit across the divider on each row
+	perRow := utils.VisibleWidth(utils.Divider()) / (len(e.reels) + 3)
+
+	var lines []string
+	for first := 0; first < len(e.machine.Paylines); first += perRow {
+		var columns [][]string
+		for i := first; i < min(first+perRow, len(e.machine.Paylines)); i++ {
+			column := []string{utils.Dim("%s", utils.PadRight(fmt.Sprint(i+1), len(e.reels)))}
+			for row := range Rows {
+				var marks strings.Builder
+				for _, lineRow := range e.machine.Paylines[i] {
+					if lineRow == row {
+						marks.WriteString("●")
+					} else {
+						marks.WriteString(utils.Dim("·"))
+					}
+				}
+				column = append(column, marks.String())
+			}
+			columns = append(columns, column)
+		}
+		lines = append(lines, utils.JoinColumns("   ", columns...)...)
+	}
+
+	return lines
+}
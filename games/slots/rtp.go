@@ -0,0 +1,178 @@
+package slots
+
+import "fmt"
+
+const (
+	// maxEnumerated is the most ways the reels can stop that CalculateRTP goes
+	// through one by one. Bigger machines are simulated instead.
+	maxEnumerated = 50_000_000
+	// simulatedSpins is how many spins CalculateRTP simulates when a machine is
+	// too big to go through every stop
+	simulatedSpins = 5_000_000
+)
+
+// Report is what a machine pays back, as fractions of the total bet
+type Report struct {
+	Machine string
+	// Exact is true if every way the reels can stop was counted, and false if
+	// the report comes from Spins simulated spins
+	Exact bool
+	Spins int
+
+	LineRTP     float64
+	ScatterRTP  float64
+	FreeSpinRTP float64
+	// HitFrequency is the chance a paid spin wins anything
+	HitFrequency float64
+	// FeatureFrequency is the chance a paid spin lands free spins
+	FeatureFrequency float64
+}
+
+// RTP is the total return to player
+func (r Report) RTP() float64 {
+	return r.LineRTP + r.ScatterRTP + r.FreeSpinRTP
+}
+
+// Describe lays out the report for printing
+func (r Report) Describe() []string {
+	method := fmt.Sprintf("counted over every one of %d stops", r.Spins)
+	if !r.Exact {
+		method = fmt.Sprintf("simulated over %d spins", r.Spins)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s, %s", r.Machine, method),
+		fmt.Sprintf("Line wins:      %7.3f%%", r.LineRTP*100),
+		fmt.Sprintf("Scatter wins:   %7.3f%%", r.ScatterRTP*100),
+		fmt.Sprintf("Free spins:     %7.3f%%", r.FreeSpinRTP*100),
+		fmt.Sprintf("Total RTP:      %7.3f%%", r.RTP()*100),
+		fmt.Sprintf("Hit frequency:  %7.3f%%", r.HitFrequency*100),
+	}
+	if r.FeatureFrequency > 0 {
+		lines = append(lines, fmt.Sprintf("Free spins land 1 in %.0f spins", 1/r.FeatureFrequency))
+	}
+
+	return lines
+}
+
+// CalculateRTP works out what a machine pays back. Every way the reels can stop
+// is counted when there aren't too many, otherwise spins are simulated.
+func CalculateRTP(m Machine) (Report, error) {
+	e, err := newEngine(m)
+	if err != nil {
+		return Report{}, err
+	}
+
+	stops := 1
+	for _, reel := range e.reels {
+		stops *= len(reel)
+		if stops > maxEnumerated {
+			return e.simulate(simulatedSpins), nil
+		}
+	}
+
+	return e.enumerate(stops), nil
+}
+
+// Simulate plays spins paid spins, and the free spins they land, and reports
+// what they paid back
+func Simulate(m Machine, spins int) (Report, error) {
+	e, err := newEngine(m)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return e.simulate(max(1, spins)), nil
+}
+
+// enumerate counts every way the reels can stop. Free spins are played on the
+// same reels and don't land more free spins, so each is worth an ordinary spin
+// times the multiplier.
+func (e *engine) enumerate(stops int) Report {
+	lines := len(e.machine.Paylines)
+	grid := make(Grid, len(e.reels))
+
+	var total, lineWins, scatterWins, hits, features float64
+	var visit func(reel int, weight float64)
+	visit = func(reel int, weight float64) {
+		if reel < len(e.reels) {
+			for stop, stopWeight := range e.weights[reel] {
+				grid[reel] = e.column(reel, stop)
+				visit(reel+1, weight*float64(stopWeight))
+			}
+			return
+		}
+
+		total += weight
+		linePays := 0
+		for line := range lines {
+			_, _, pays := e.linePay(grid, line)
+			linePays += pays
+		}
+		lineWins += weight * float64(linePays)
+
+		scatters := e.countScatters(grid)
+		scatterPays := 0
+		if scatters > 0 {
+			scatterPays = e.pays[e.scatter][min(scatters, len(e.reels))]
+		}
+		scatterWins += weight * float64(scatterPays*lines)
+
+		triggered := e.machine.FreeSpins.Scatters > 0 && scatters >= e.machine.FreeSpins.Scatters
+		if triggered {
+			features += weight
+		}
+		if linePays > 0 || scatterPays > 0 || triggered {
+			hits += weight
+		}
+	}
+	visit(0, 1)
+
+	report := Report{
+		Machine:          e.machine.Name,
+		Exact:            true,
+		Spins:            stops,
+		LineRTP:          lineWins / total / float64(lines),
+		ScatterRTP:       scatterWins / total / float64(lines),
+		HitFrequency:     hits / total,
+		FeatureFrequency: features / total,
+	}
+	fs := e.machine.FreeSpins
+	report.FreeSpinRTP = report.FeatureFrequency * float64(fs.Spins*fs.Multiplier) * (report.LineRTP + report.ScatterRTP)
+
+	return report
+}
+
+// simulate plays spins paid spins and the free spins they land
+func (e *engine) simulate(spins int) Report {
+	lines := len(e.machine.Paylines)
+
+	var lineWins, scatterWins, freeSpinWins, hits, features int
+	for range spins {
+		outcome := e.evaluate(e.window(e.spin()))
+		lineWins += outcome.Won(lines) - outcome.ScatterPays*lines
+		scatterWins += outcome.ScatterPays * lines
+		if outcome.Won(lines) > 0 || outcome.FreeSpins > 0 {
+			hits++
+		}
+
+		if outcome.FreeSpins > 0 {
+			features++
+			for range outcome.FreeSpins {
+				free := e.evaluate(e.window(e.spin()))
+				freeSpinWins += free.Won(lines) * e.machine.FreeSpins.Multiplier
+			}
+		}
+	}
+
+	bet := float64(spins * lines)
+	return Report{
+		Machine:          e.machine.Name,
+		Spins:            spins,
+		LineRTP:          float64(lineWins) / bet,
+		ScatterRTP:       float64(scatterWins) / bet,
+		FreeSpinRTP:      float64(freeSpinWins) / bet,
+		HitFrequency:     float64(hits) / float64(spins),
+		FeatureFrequency: float64(features) / float64(spins),
+	}
+}
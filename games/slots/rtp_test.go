@@ -0,0 +1,129 @@
+package slots
+
+import (
+	"math"
+	"testing"
+)
+
+// tinyMachine is small enough to work out by hand. Each reel shows three of its
+// four stops, so every symbol is equally likely on the middle row, and the
+// scatter shows on a reel unless it stops with A, B and W showing.
+func tinyMachine() Machine {
+	strip := []string{"A", "B", "W", "S"}
+	return Machine{
+		Name: "Tiny",
+		Symbols: []Symbol{
+			{Name: "A", Pays: map[int]int{3: 2}},
+			{Name: "B", Pays: map[int]int{3: 1}},
+			{Name: "W", Pays: map[int]int{3: 5}},
+			{Name: "S", Pays: map[int]int{3: 1}},
+		},
+		Reels:      []Reel{{Strip: strip}, {Strip: strip}, {Strip: strip}},
+		Paylines:   [][]int{{1, 1, 1}},
+		Wild:       "W",
+		Scatter:    "S",
+		FreeSpins:  FreeSpins{Scatters: 3, Spins: 1, Multiplier: 1},
+		MaxLineBet: 1,
+	}
+}
+
+// Worked out for tinyMachine over its 64 stops. The middle row pays on WWW (5),
+// the 7 lines of A and W with at least one A (2 each) and the 7 of B and W (1
+// each). The scatter shows on each reel 3 times in 4, so all three show 27
+// times, paying 1 and a free spin worth an average paid spin.
+const (
+	tinyLineRTP     = (5 + 7*2 + 7*1) / 64.0
+	tinyScatterRTP  = 27 / 64.0
+	tinyFeatures    = 27 / 64.0
+	tinyFreeSpinRTP = tinyFeatures * (tinyLineRTP + tinyScatterRTP)
+	// 15 stops win on the line and 27 land free spins. 8 do both: WWW and the 7
+	// A and W lines, which never leave a reel without its scatter.
+	tinyHitFrequency = (15 + 27 - 8) / 64.0
+)
+
+func TestCalculateRTP(t *testing.T) {
+	report, err := CalculateRTP(tinyMachine())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Exact || report.Spins != 64 {
+		t.Errorf("report counted %d stops, exact %t, want all 64", report.Spins, report.Exact)
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"line RTP", report.LineRTP, tinyLineRTP},
+		{"scatter RTP", report.ScatterRTP, tinyScatterRTP},
+		{"free spin RTP", report.FreeSpinRTP, tinyFreeSpinRTP},
+		{"hit frequency", report.HitFrequency, tinyHitFrequency},
+		{"feature frequency", report.FeatureFrequency, tinyFeatures},
+	}
+	for _, check := range checks {
+		if math.Abs(check.got-check.want) > 1e-12 {
+			t.Errorf("%s = %.6f, want %.6f", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestCalculateRTPWeightedReels(t *testing.T) {
+	// Tripling every stop's weight changes nothing
+	machine := tinyMachine()
+	for i := range machine.Reels {
+		machine.Reels[i].Weights = []int{3, 3, 3, 3}
+	}
+	report, err := CalculateRTP(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := tinyLineRTP + tinyScatterRTP + tinyFreeSpinRTP; math.Abs(report.RTP()-want) > 1e-12 {
+		t.Errorf("RTP = %.6f, want %.6f", report.RTP(), want)
+	}
+
+	// Stopping on the first stop, with A, B and W showing, half the time hides
+	// the scatter and puts B on the middle row
+	for i := range machine.Reels {
+		machine.Reels[i].Weights = []int{3, 1, 1, 1}
+	}
+	report, err = CalculateRTP(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The middle row is B half the time and A, W or S a sixth of the time each
+	b, other := 0.5, 1/6.0
+	lineRTP := 5*math.Pow(other, 3) +
+		2*(math.Pow(2*other, 3)-math.Pow(other, 3)) +
+		1*(math.Pow(b+other, 3)-math.Pow(other, 3))
+	if math.Abs(report.LineRTP-lineRTP) > 1e-12 {
+		t.Errorf("weighted line RTP = %.6f, want %.6f", report.LineRTP, lineRTP)
+	}
+	if want := math.Pow(0.5, 3); math.Abs(report.FeatureFrequency-want) > 1e-12 {
+		t.Errorf("weighted feature frequency = %.6f, want %.6f", report.FeatureFrequency, want)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	report, err := Simulate(tinyMachine(), 200_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Exact {
+		t.Error("a simulated report says it's exact")
+	}
+
+	// Several standard errors wide, so this only fails if the simulation is off
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"RTP", report.RTP(), tinyLineRTP + tinyScatterRTP + tinyFreeSpinRTP},
+		{"hit frequency", report.HitFrequency, tinyHitFrequency},
+		{"feature frequency", report.FeatureFrequency, tinyFeatures},
+	}
+	for _, check := range checks {
+		if math.Abs(check.got-check.want) > 0.02 {
+			t.Errorf("simulated %s = %.4f, want %.4f ± 0.02", check.name, check.got, check.want)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package slots
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"casino/games"
+	"casino/utils"
+)
+
+const (
+	// frameDelay is how long each frame of the spinning reels is shown
+	frameDelay = 60 * time.Millisecond
+	// framesPerReel is how many more frames each reel spins than the one to its left
+	framesPerReel = 5
+	// freeSpinDelay is the pause between free spins so each result can be seen
+	freeSpinDelay = 1500 * time.Millisecond
+)
+
+type slots struct {
+	saveManager utils.SaveDataManager
+	machine     Machine
+	engine      *engine
+
+	lineBet   int
+	userChips int
+
+	in  chan string
+	out chan string
+}
+
+func NewSlots(
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	s := &slots{
+		saveManager: saveManager,
+		machine:     DefaultMachine(),
+		in:          in,
+		out:         out,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Option configures a slots game
+type Option func(*slots)
+
+// WithMachine sets the machine played. It should already be validated, see
+// LoadMachine.
+func WithMachine(machine Machine) Option {
+	return func(s *slots) { s.machine = machine }
+}
+
+func (s *slots) Name() string {
+	return "Slots"
+}
+
+// Play runs spins until the player chooses to leave the machine. Every spin
+// returns here before the next one starts.
+func (s *slots) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := s.saveManager.Read().RemainingChips
+
+	e, err := newEngine(s.machine)
+	if err != nil {
+		return result, err
+	}
+	s.engine = e
+
+	for {
+		played, err := s.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = s.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = s.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the bet, spins the reels and pays, then plays any free spins
+// landed. Returns whether the reels were spun. Once the bet is in the spin and
+// its free spins are always paid, even if ctx is canceled part way through.
+func (s *slots) playRound(ctx context.Context) (bool, error) {
+	utils.Clear(s.out)
+	s.userChips = s.saveManager.Read().RemainingChips
+
+	utils.PrintBanner(s.machine.Name, s.out)
+	for _, line := range s.engine.renderPaytable() {
+		s.out <- line
+	}
+	s.out <- utils.Divider()
+	for _, line := range s.engine.renderPaylines() {
+		s.out <- line
+	}
+	s.out <- utils.Divider()
+
+	lines := len(s.machine.Paylines)
+	if s.userChips < lines {
+		s.out <- utils.Red("You need at least %d chips to play", lines)
+		return false, nil
+	}
+	if err := s.bet(ctx); err != nil {
+		return false, err
+	}
+
+	outcome := s.spin(ctx, "")
+	s.payout(outcome, 1)
+
+	if outcome.FreeSpins > 0 {
+		s.playFreeSpins(ctx, outcome.FreeSpins)
+	}
+
+	return true, nil
+}
+
+// bet asks the player how much to bet on each line. Every line is played.
+func (s *slots) bet(ctx context.Context) error {
+	lines := len(s.machine.Paylines)
+	maxLineBet := min(s.machine.MaxLineBet, s.userChips/lines)
+	s.out <- utils.Dim("You have %d chips, playing %d lines", s.userChips, lines)
+	lineBet, err := utils.GetBet(
+		ctx,
+		s.in,
+		s.out,
+		fmt.Sprintf("How much a line? (1-%d, %d lines)", maxLineBet, lines),
+		1,
+		maxLineBet,
+	)
+	if err != nil {
+		return err
+	}
+	s.lineBet = lineBet
+
+	save := s.saveManager.Read()
+	save.RemainingChips -= s.lineBet * lines
+	s.saveManager.Save(save)
+	s.userChips = save.RemainingChips
+
+	return nil
+}
+
+// spin shows the reels spinning and stopping one at a time, left to right, and
+// returns what they landed on. The animation is skipped if ctx is canceled.
+func (s *slots) spin(ctx context.Context, status string) Outcome {
+	stops := s.engine.spin()
+
+	utils.Clear(s.out)
+	s.out <- utils.Dim("Your chips: %d", s.userChips)
+	s.out <- utils.Dim("Bet: %d a line, %d lines", s.lineBet, len(s.machine.Paylines))
+	if status != "" {
+		s.out <- utils.Yellow(utils.Bold("%s", status))
+	}
+	s.out <- utils.Divider()
+
+	// Each reel starts further up its strip and scrolls down to its stop
+	frames := framesPerReel * len(stops)
+	grid := make(Grid, len(stops))
+	height := 0
+	for frame := frames; frame >= 0; frame-- {
+		if ctx.Err() != nil {
+			frame = 0
+		}
+		for reel, stop := range stops {
+			ahead := max(0, frame-framesPerReel*(len(stops)-1-reel))
+			grid[reel] = s.engine.column(reel, stop+ahead)
+		}
+
+		lines := s.engine.renderGrid(grid, nil)
+		if height == 0 {
+			for _, line := range lines {
+				s.out <- line
+			}
+		} else {
+			s.out <- utils.Redraw(height, lines)
+		}
+		height = len(lines)
+
+		if frame > 0 {
+			utils.Sleep(ctx, frameDelay)
+		}
+	}
+
+	outcome := s.engine.evaluate(grid)
+	winning := map[[2]int]bool{}
+	for _, win := range outcome.Lines {
+		for reel, row := range s.machine.Paylines[win.Line][:win.Count] {
+			winning[[2]int{reel, row}] = true
+		}
+	}
+	for reel, column := range grid {
+		for row, symbol := range column {
+			if symbol == s.engine.scatter && outcome.ScatterPays+outcome.FreeSpins > 0 {
+				winning[[2]int{reel, row}] = true
+			}
+		}
+	}
+	s.out <- utils.Redraw(height, s.engine.renderGrid(grid, winning))
+	s.out <- utils.Divider()
+
+	return outcome
+}
+
+// payout pays what a spin won, times multiplier
+func (s *slots) payout(outcome Outcome, multiplier int) int {
+	for _, win := range outcome.Lines {
+		symbol := s.machine.Symbols[win.Symbol]
+		s.out <- symbolColor[symbol.Color]("Line %d: %d %s pays %d", win.Line+1, win.Count, symbol.Name, win.Pays*s.lineBet*multiplier)
+	}
+	if outcome.ScatterPays > 0 {
+		s.out <- utils.Cyan("%d %s pay %d", outcome.Scatters, s.machine.Scatter, outcome.ScatterPays*s.lineBet*len(s.machine.Paylines)*multiplier)
+	}
+
+	won := outcome.Won(len(s.machine.Paylines)) * s.lineBet * multiplier
+	if won == 0 {
+		if multiplier == 1 && outcome.FreeSpins == 0 {
+			s.out <- utils.Red("No win (-%d chips)", s.lineBet*len(s.machine.Paylines))
+		}
+		return 0
+	}
+
+	save := s.saveManager.Read()
+	save.RemainingChips += won
+	s.saveManager.Save(save)
+	s.userChips = save.RemainingChips
+
+	s.out <- utils.Green(utils.Bold("You win %d chips!", won))
+	return won
+}
+
+// playFreeSpins plays the free spins the player landed, one after another. Free
+// spins can't land more free spins, though scatters still pay.
+func (s *slots) playFreeSpins(ctx context.Context, spins int) {
+	fs := s.machine.FreeSpins
+	s.out <- utils.Yellow(utils.Bold("%d free spins, every win pays %dx!", spins, fs.Multiplier))
+	utils.Sleep(ctx, freeSpinDelay)
+
+	total := 0
+	for spin := range spins {
+		outcome := s.spin(ctx, fmt.Sprintf("Free spin %d of %d, %dx (won %d so far)", spin+1, spins, fs.Multiplier, total))
+		outcome.FreeSpins = 0
+		total += s.payout(outcome, fs.Multiplier)
+		utils.Sleep(ctx, freeSpinDelay)
+	}
+
+	s.out <- utils.Yellow(utils.Bold("Free spins won %d chips", total))
+}
+
+// playAgain asks whether the player wants another spin. Returns false if they
+// want to leave the machine.
+func (s *slots) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		s.in,
+		s.out,
+		[]string{"yes", "y", "no", "n"},
+		"Spin again? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
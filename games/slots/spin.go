@@ -0,0 +1,148 @@
+package slots
+
+import "math/rand"
+
+// Grid is the symbols showing after a spin, by reel then row, as indexes into
+// the machine's symbols
+type Grid [][]int
+
+// LineWin is a payline that won
+type LineWin struct {
+	Line   int // index into the machine's paylines
+	Symbol int
+	Count  int
+	Pays   int // times the line bet
+}
+
+// Outcome is everything a spin won
+type Outcome struct {
+	Lines    []LineWin
+	Scatters int
+	// ScatterPays is what the scatters won, times the total bet
+	ScatterPays int
+	FreeSpins   int
+}
+
+// Won is how many line bets the outcome pays when the machine has lines
+// paylines, before any free spin multiplier
+func (o Outcome) Won(lines int) int {
+	won := o.ScatterPays * lines
+	for _, line := range o.Lines {
+		won += line.Pays
+	}
+
+	return won
+}
+
+// spin picks where each reel stops. Returns the stop showing in the top row of
+// each reel.
+func (e *engine) spin() []int {
+	stops := make([]int, len(e.reels))
+	for reel, weights := range e.weights {
+		pick := rand.Intn(e.totals[reel])
+		for stop, weight := range weights {
+			if pick < weight {
+				stops[reel] = stop
+				break
+			}
+			pick -= weight
+		}
+	}
+
+	return stops
+}
+
+// window is what shows on the machine when the reels stop at stops
+func (e *engine) window(stops []int) Grid {
+	grid := make(Grid, len(e.reels))
+	for reel, stop := range stops {
+		grid[reel] = e.column(reel, stop)
+	}
+
+	return grid
+}
+
+// column is the Rows symbols showing on a reel stopped at stop
+func (e *engine) column(reel, stop int) []int {
+	strip := e.reels[reel]
+	column := make([]int, Rows)
+	for row := range column {
+		column[row] = strip[(stop+row)%len(strip)]
+	}
+
+	return column
+}
+
+// evaluate works out what a grid pays
+func (e *engine) evaluate(grid Grid) Outcome {
+	var outcome Outcome
+	for i := range e.machine.Paylines {
+		if symbol, count, pays := e.linePay(grid, i); pays > 0 {
+			outcome.Lines = append(outcome.Lines, LineWin{Line: i, Symbol: symbol, Count: count, Pays: pays})
+		}
+	}
+
+	outcome.Scatters = e.countScatters(grid)
+	if outcome.Scatters > 0 {
+		outcome.ScatterPays = e.pays[e.scatter][min(outcome.Scatters, len(e.reels))]
+	}
+	if fs := e.machine.FreeSpins; fs.Scatters > 0 && outcome.Scatters >= fs.Scatters {
+		outcome.FreeSpins = fs.Spins
+	}
+
+	return outcome
+}
+
+// linePay works out what a payline pays, counting matching symbols from the
+// leftmost reel. Wilds stand in for any symbol but the scatter, and a line of
+// wilds is paid as wilds if that pays more than what they complete.
+func (e *engine) linePay(grid Grid, line int) (symbol, count, pays int) {
+	rows := e.machine.Paylines[line]
+
+	wilds := 0
+	for wilds < len(rows) && grid[wilds][rows[wilds]] == e.wild {
+		wilds++
+	}
+	if e.wild != noSymbol && wilds > 0 {
+		symbol, count, pays = e.wild, wilds, e.pays[e.wild][wilds]
+	}
+	if wilds == len(rows) {
+		return symbol, count, pays
+	}
+
+	first := grid[wilds][rows[wilds]]
+	if first == e.scatter {
+		return symbol, count, pays
+	}
+	matched := wilds + 1
+	for matched < len(rows) {
+		next := grid[matched][rows[matched]]
+		if next != first && next != e.wild {
+			break
+		}
+		matched++
+	}
+
+	if linePays := e.pays[first][matched]; linePays > pays {
+		return first, matched, linePays
+	}
+	return symbol, count, pays
+}
+
+// countScatters counts the scatters anywhere on the grid
+func (e *engine) countScatters(grid Grid) int {
+	if e.scatter == noSymbol {
+		return 0
+	}
+
+	count := 0
+	for _, column := range grid {
+		for _, symbol := range column {
+			if symbol == e.scatter {
+				count++
+			}
+		}
+	}
+
+	return count
+}
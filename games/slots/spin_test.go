@@ -0,0 +1,145 @@
+package slots
+
+import (
+	"strings"
+	"testing"
+)
+
+// lineMachine is a 5 reel machine for testing line pays. Its reels don't matter
+// here, only its symbols and paylines.
+func lineMachine() Machine {
+	strip := []string{"A", "B", "W", "S"}
+	return Machine{
+		Name: "Lines",
+		Symbols: []Symbol{
+			{Name: "A", Pays: map[int]int{3: 5, 4: 10, 5: 50}},
+			{Name: "B", Pays: map[int]int{3: 2, 4: 4, 5: 8}},
+			{Name: "W", Pays: map[int]int{3: 20, 4: 100, 5: 1000}},
+			{Name: "S", Pays: map[int]int{3: 2, 5: 10}},
+		},
+		Reels:      []Reel{{Strip: strip}, {Strip: strip}, {Strip: strip}, {Strip: strip}, {Strip: strip}},
+		Paylines:   [][]int{{1, 1, 1, 1, 1}, {0, 1, 2, 1, 0}},
+		Wild:       "W",
+		Scatter:    "S",
+		MaxLineBet: 1,
+	}
+}
+
+// grid builds a grid from its rows, top first, e.g. "BBBBB", "AAWAB", "BBBBB"
+func grid(t *testing.T, e *engine, rows ...string) Grid {
+	t.Helper()
+
+	index := map[rune]int{}
+	for i, symbol := range e.machine.Symbols {
+		index[rune(symbol.Name[0])] = i
+	}
+
+	g := make(Grid, len(e.reels))
+	for reel := range g {
+		g[reel] = make([]int, Rows)
+		for row, symbols := range rows {
+			symbol, ok := index[rune(symbols[reel])]
+			if !ok {
+				t.Fatalf("unknown symbol %c", symbols[reel])
+			}
+			g[reel][row] = symbol
+		}
+	}
+
+	return g
+}
+
+func TestLinePay(t *testing.T) {
+	e, err := newEngine(lineMachine())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		middle string
+		symbol string
+		count  int
+		pays   int
+	}{
+		{"three of a kind", "AAABB", "A", 3, 5},
+		{"five of a kind", "BBBBB", "B", 5, 8},
+		{"wild completes a line", "AWAAB", "A", 4, 10},
+		{"wilds lead a line", "WWAAA", "A", 5, 50},
+		{"wilds pay more than the line they lead", "WWWAB", "W", 3, 20},
+		{"line of wilds pays more than it completes", "WWWWA", "W", 4, 100},
+		{"five wilds", "WWWWW", "W", 5, 1000},
+		{"scatter cuts a line", "AASAA", "", 0, 0},
+		{"wild doesn't stand in for the scatter", "WWSSS", "", 0, 0},
+		{"too short to pay", "AABAA", "", 0, 0},
+		{"counted from the leftmost reel", "BAAAA", "", 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := grid(t, e, "BABAB", test.middle, "BABAB")
+			symbol, count, pays := e.linePay(g, 0)
+			if pays != test.pays {
+				t.Fatalf("%s pays %d, want %d", test.middle, pays, test.pays)
+			}
+			if pays > 0 && (e.machine.Symbols[symbol].Name != test.symbol || count != test.count) {
+				t.Errorf("%s paid as %d %s, want %d %s",
+					test.middle, count, e.machine.Symbols[symbol].Name, test.count, test.symbol)
+			}
+		})
+	}
+}
+
+func TestLinePayFollowsPayline(t *testing.T) {
+	e, err := newEngine(lineMachine())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The V line crosses the top row on the outside reels and the bottom in the
+	// middle
+	g := grid(t, e, "ABBBA", "BABAB", "BBABB")
+	if _, count, pays := e.linePay(g, 1); count != 5 || pays != 50 {
+		t.Errorf("V line paid %d for %d, want 50 for 5 A", pays, count)
+	}
+	if _, _, pays := e.linePay(g, 0); pays != 0 {
+		t.Errorf("middle line paid %d, want 0", pays)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	machine := lineMachine()
+	machine.FreeSpins = FreeSpins{Scatters: 3, Spins: 5, Multiplier: 2}
+	e, err := newEngine(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		rows        []string
+		scatters    int
+		scatterPays int
+		freeSpins   int
+		won         int // line bets won on the machine's two paylines
+	}{
+		{"two scatters", []string{"SBBAB", "BABAB", "BBSBB"}, 2, 0, 0, 0},
+		{"three scatters land free spins", []string{"SBBAB", "BABSB", "BBSBB"}, 3, 2, 5, 4},
+		{"more scatters than reels pay as many as reels", []string{"SSSSS", "SSBAB", "BBBBB"}, 7, 10, 5, 20},
+		{"line win and scatters", []string{"SBSAB", "AAAAS", "BBBBB"}, 3, 2, 5, 10 + 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outcome := e.evaluate(grid(t, e, test.rows...))
+			if outcome.Scatters != test.scatters || outcome.ScatterPays != test.scatterPays || outcome.FreeSpins != test.freeSpins {
+				t.Errorf("%s: %d scatters paying %d with %d free spins, want %d paying %d with %d",
+					strings.Join(test.rows, "/"), outcome.Scatters, outcome.ScatterPays, outcome.FreeSpins,
+					test.scatters, test.scatterPays, test.freeSpins)
+			}
+			if won := outcome.Won(len(machine.Paylines)); won != test.won {
+				t.Errorf("%s won %d line bets, want %d", strings.Join(test.rows, "/"), won, test.won)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package videopoker
+
+import "casino/evaluator"
+
+const (
+	MinCredits = 1
+	MaxCredits = 5
+	// ChipsPerCredit is what one credit costs
+	ChipsPerCredit = 5
+	// HandSize is how many cards are dealt
+	HandSize = 5
+)
+
+// jacksRank is the lowest pair that pays, as ranked by evaluator.CardRank
+const jacksRank = 10
+
+// Paytable is how many credits each hand pays per credit bet, including the bet
+// itself. A pair only pays if it's jacks or better. Hands that aren't listed
+// lose.
+type Paytable struct {
+	Name  string
+	Hands map[evaluator.Category]int
+	// MaxBetRoyal is what a royal flush pays in total when MaxCredits are bet
+	MaxBetRoyal int
+}
+
+// NineSixJacks is full pay Jacks or Better: 9 for a full house and 6 for a flush
+func NineSixJacks() Paytable {
+	return Paytable{
+		Name: "Jacks or Better 9/6",
+		Hands: map[evaluator.Category]int{
+			evaluator.Pair:          1,
+			evaluator.TwoPair:       2,
+			evaluator.ThreeOfAKind:  3,
+			evaluator.Straight:      4,
+			evaluator.Flush:         6,
+			evaluator.FullHouse:     9,
+			evaluator.FourOfAKind:   25,
+			evaluator.StraightFlush: 50,
+			evaluator.RoyalFlush:    250,
+		},
+		MaxBetRoyal: 4000,
+	}
+}
+
+// Payout is how many credits a hand pays for a bet, including the bet itself
+func (p Paytable) Payout(rank evaluator.Rank, credits int) int {
+	category := evaluator.Category(rank.Category())
+	if category == evaluator.Pair && rank.Kickers()[0] < jacksRank {
+		return 0
+	}
+
+	return p.CategoryPayout(category, credits)
+}
+
+// CategoryPayout is how many credits a category of hand pays for a bet,
+// including the bet itself. Pairs are assumed to be jacks or better.
+func (p Paytable) CategoryPayout(category evaluator.Category, credits int) int {
+	if category == evaluator.RoyalFlush && credits == MaxCredits {
+		return p.MaxBetRoyal
+	}
+
+	return p.Hands[category] * credits
+}
+
+// HandName is what the machine calls a hand, or "" if it doesn't pay
+func (p Paytable) HandName(rank evaluator.Rank) string {
+	if p.Payout(rank, MinCredits) == 0 {
+		return ""
+	}
+
+	return categoryName(evaluator.Category(rank.Category()))
+}
+
+func categoryName(category evaluator.Category) string {
+	if category == evaluator.Pair {
+		return "Jacks or better"
+	}
+
+	return category.String()
+}
@@ -0,0 +1,71 @@
+package videopoker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"casino/evaluator"
+)
+
+// paytableFile is a paytable as it's written in JSON, with hands by name
+type paytableFile struct {
+	Name        string         `json:"name"`
+	Hands       map[string]int `json:"hands"`
+	MaxBetRoyal *int           `json:"maxBetRoyal"`
+}
+
+// LoadPaytable reads a paytable from a JSON file. Hands are named the way the
+// machine shows them, in any case, and pay per credit bet, e.g.
+// {"name": "Jacks or Better 8/5", "hands": {"full house": 8, "flush": 5, ...}}.
+// Anything left out of the file keeps its 9/6 value.
+func LoadPaytable(path string) (Paytable, error) {
+	paytable := NineSixJacks()
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return paytable, err
+	}
+	var file paytableFile
+	if err := json.Unmarshal(bytes, &file); err != nil {
+		return paytable, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if file.Hands != nil {
+		paytable.Name = "Jacks or Better"
+		paytable.Hands = map[evaluator.Category]int{}
+		for name, pays := range file.Hands {
+			category, ok := findCategory(name)
+			if !ok {
+				return paytable, fmt.Errorf("unknown hand %q", name)
+			}
+			if pays < 1 {
+				return paytable, fmt.Errorf("%s must pay at least 1 credit, got %d", name, pays)
+			}
+			paytable.Hands[category] = pays
+		}
+	}
+	if file.Name != "" {
+		paytable.Name = file.Name
+	}
+	if file.MaxBetRoyal != nil {
+		paytable.MaxBetRoyal = *file.MaxBetRoyal
+	}
+	if paytable.MaxBetRoyal < 1 {
+		return paytable, fmt.Errorf("maxBetRoyal must be at least 1, got %d", paytable.MaxBetRoyal)
+	}
+
+	return paytable, nil
+}
+
+// findCategory looks up a hand by the name the machine gives it
+func findCategory(name string) (evaluator.Category, bool) {
+	for category := evaluator.Pair; category <= evaluator.RoyalFlush; category++ {
+		if strings.EqualFold(strings.TrimSpace(name), categoryName(category)) {
+			return category, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,315 @@
+package videopoker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"casino/entities"
+	"casino/evaluator"
+	"casino/games"
+	"casino/utils"
+)
+
+// cardWidth is how wide utils.RenderCard draws a card, plus the gap after it
+const cardWidth = 12
+
+type videoPoker struct {
+	dealer      utils.Dealer
+	saveManager utils.SaveDataManager
+	paytable    Paytable
+
+	hand      entities.Hand
+	held      [HandSize]bool
+	credits   int
+	userChips int
+
+	in  chan string
+	out chan string
+}
+
+func NewVideoPoker(
+	dealer utils.Dealer,
+	saveManager utils.SaveDataManager,
+	in chan string,
+	out chan string,
+	opts ...Option,
+) games.Game {
+	v := &videoPoker{
+		dealer:      dealer,
+		saveManager: saveManager,
+		paytable:    NineSixJacks(),
+		in:          in,
+		out:         out,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Option configures a video poker game
+type Option func(*videoPoker)
+
+// WithPaytable sets what the machine pays
+func WithPaytable(paytable Paytable) Option {
+	return func(v *videoPoker) { v.paytable = paytable }
+}
+
+func (v *videoPoker) Name() string {
+	return "Jacks or Better"
+}
+
+// Play runs hands until the player chooses to leave the machine. Every hand
+// returns here before the next one starts.
+func (v *videoPoker) Play(ctx context.Context) (games.Result, error) {
+	var result games.Result
+	startingChips := v.saveManager.Read().RemainingChips
+
+	for {
+		played, err := v.playRound(ctx)
+		if played {
+			result.Rounds++
+		}
+
+		again := false
+		if err == nil {
+			again, err = v.playAgain(ctx)
+		}
+		if err != nil || !again {
+			result.NetChips = v.saveManager.Read().RemainingChips - startingChips
+			return result, err
+		}
+	}
+}
+
+// playRound takes the bet, deals a hand, lets the player hold and draw, and pays
+// it. Returns whether a hand was dealt. Once the cards are out the hand is
+// always paid, even if ctx is canceled part way through.
+func (v *videoPoker) playRound(ctx context.Context) (bool, error) {
+	utils.Clear(v.out)
+	save := v.saveManager.Read()
+	v.userChips = save.RemainingChips
+
+	utils.PrintBanner(v.Name(), v.out)
+	v.printPaytable()
+
+	if v.userChips < ChipsPerCredit {
+		v.out <- utils.Red("You need at least %d chips to play", ChipsPerCredit)
+		return false, nil
+	}
+	if err := v.bet(ctx); err != nil {
+		return false, err
+	}
+
+	if err := v.deal(); err != nil {
+		v.misdeal(err)
+		return true, nil
+	}
+
+	v.chooseHolds(ctx)
+	if err := v.draw(); err != nil {
+		v.misdeal(err)
+		return true, nil
+	}
+	v.payout()
+	v.endGame()
+
+	return true, nil
+}
+
+// bet asks the player how many credits to play
+func (v *videoPoker) bet(ctx context.Context) error {
+	maxCredits := min(MaxCredits, v.userChips/ChipsPerCredit)
+	v.out <- utils.Dim("You have %d chips, %d chips a credit", v.userChips, ChipsPerCredit)
+	credits, err := utils.GetBet(
+		ctx,
+		v.in,
+		v.out,
+		fmt.Sprintf("How many credits? (%d-%d, %d for the royal bonus)", MinCredits, maxCredits, MaxCredits),
+		MinCredits,
+		maxCredits,
+	)
+	if err != nil {
+		return err
+	}
+	v.credits = credits
+
+	save := v.saveManager.Read()
+	save.RemainingChips -= v.credits * ChipsPerCredit
+	v.saveManager.Save(save)
+	v.userChips = save.RemainingChips
+
+	return nil
+}
+
+// deal shuffles the deck and deals a fresh hand. Every hand is dealt from a full
+// deck.
+func (v *videoPoker) deal() error {
+	v.dealer.Shuffle()
+	v.held = [HandSize]bool{}
+
+	cards, err := v.dealer.DrawCards(entities.DrawOpts{Count: HandSize})
+	v.hand = entities.Hand{Cards: cards}
+
+	return err
+}
+
+// chooseHolds lets the player toggle which cards to keep until they draw. If
+// they stop answering the cards held so far are kept.
+func (v *videoPoker) chooseHolds(ctx context.Context) {
+	for {
+		v.printUpdate()
+		if name := v.paytable.HandName(evaluator.Evaluate(v.hand.Cards)); name != "" {
+			v.out <- utils.Green("Dealt: %s", name)
+		}
+		v.out <- fmt.Sprintf("Toggle holds by position (1-%d, e.g. 134), or draw (d)", HandSize)
+
+		line, err := utils.ReadLine(ctx, v.in)
+		if err != nil {
+			return
+		}
+
+		input := strings.ToLower(strings.TrimSpace(line))
+		if input == "d" || input == "draw" || input == "" {
+			return
+		}
+		for _, position := range strings.ReplaceAll(input, " ", "") {
+			i := int(position - '1')
+			if i < 0 || i >= HandSize {
+				continue
+			}
+			v.held[i] = !v.held[i]
+		}
+	}
+}
+
+// draw replaces every card that isn't held
+func (v *videoPoker) draw() error {
+	for i, held := range v.held {
+		if held {
+			continue
+		}
+
+		card, err := v.dealer.Draw()
+		if err != nil {
+			return err
+		}
+		v.dealer.Discard(v.hand.Cards[i])
+		v.hand.Cards[i] = card
+	}
+
+	return nil
+}
+
+// payout pays the final hand from the paytable
+func (v *videoPoker) payout() {
+	// The holds have done their job, so the final hand is shown without them
+	v.held = [HandSize]bool{}
+	v.printUpdate()
+
+	rank := evaluator.Evaluate(v.hand.Cards)
+	won := v.paytable.Payout(rank, v.credits)
+	if won == 0 {
+		v.out <- utils.Red("No win (-%d chips)", v.credits*ChipsPerCredit)
+		return
+	}
+
+	chips := won * ChipsPerCredit
+	v.out <- utils.Green(utils.Bold("%s pays %d credits! (+%d chips)", v.paytable.HandName(rank), won, chips))
+
+	save := v.saveManager.Read()
+	save.RemainingChips += chips
+	v.saveManager.Save(save)
+	v.userChips = save.RemainingChips
+}
+
+// misdeal calls off the hand when the dealer can't deal and gives back the bet
+func (v *videoPoker) misdeal(err error) {
+	refund := v.credits * ChipsPerCredit
+	save := v.saveManager.Read()
+	save.RemainingChips += refund
+	v.saveManager.Save(save)
+	v.userChips = save.RemainingChips
+
+	v.out <- utils.Red("Misdeal: %s", err.Error())
+	v.out <- utils.Yellow("Your bet was returned (+%d chips)", refund)
+	v.endGame()
+}
+
+// endGame gathers up the cards once the hand is paid
+func (v *videoPoker) endGame() {
+	v.dealer.Discard(v.hand.Cards...)
+	v.hand = entities.Hand{}
+}
+
+// playAgain asks whether the player wants another hand. Returns false if they
+// want to leave the machine.
+func (v *videoPoker) playAgain(ctx context.Context) (bool, error) {
+	playAgainChoice, err := utils.GetInput(
+		ctx,
+		v.in,
+		v.out,
+		[]string{"yes", "y", "no", "n"},
+		"Play again? Yes (y) or no (n)",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	switch playAgainChoice {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// printPaytable shows what each hand pays for every bet from MinCredits to
+// MaxCredits, best hand first
+func (v *videoPoker) printPaytable() {
+	header := utils.PadRight(v.paytable.Name, 20)
+	for credits := MinCredits; credits <= MaxCredits; credits++ {
+		header += fmt.Sprintf("%6d", credits)
+	}
+	v.out <- utils.Bold("%s", header)
+
+	for category := evaluator.RoyalFlush; category > evaluator.HighCard; category-- {
+		if _, ok := v.paytable.Hands[category]; !ok {
+			continue
+		}
+
+		row := utils.PadRight(categoryName(category), 20)
+		for credits := MinCredits; credits <= MaxCredits; credits++ {
+			row += fmt.Sprintf("%6d", v.paytable.CategoryPayout(category, credits))
+		}
+		v.out <- row
+	}
+	v.out <- utils.Divider()
+}
+
+func (v *videoPoker) printUpdate() {
+	utils.Clear(v.out)
+
+	v.out <- utils.Dim("Your chips: %d", v.userChips)
+	v.out <- utils.Dim("Credits bet: %d", v.credits)
+	v.out <- utils.Divider()
+
+	for _, line := range utils.RenderHand(v.hand) {
+		v.out <- line
+	}
+
+	// Under each card: HELD if it's held, otherwise its position
+	var marks []string
+	for i, held := range v.held {
+		mark := utils.Dim("%s", utils.PadCenter(fmt.Sprint(i+1), cardWidth-1))
+		if held {
+			mark = utils.Yellow(utils.Bold("%s", utils.PadCenter("HELD", cardWidth-1)))
+		}
+		marks = append(marks, mark)
+	}
+	v.out <- strings.Join(marks[:min(len(marks), len(v.hand.Cards))], " ")
+
+	v.out <- utils.Divider()
+}
@@ -2,22 +2,62 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"casino/entities"
 	"casino/games"
+	"casino/games/baccarat"
 	"casino/games/blackjack"
+	"casino/games/craps"
+	"casino/games/holdem"
+	"casino/games/keno"
 	"casino/games/poker"
+	"casino/games/roulette"
+	"casino/games/slots"
+	"casino/games/videopoker"
 	"casino/utils"
 )
 
+const (
+	storageFile   = "file"
+	storageMemory = "memory"
+)
+
 func main() {
+	defaultStorage := os.Getenv("CASINO_STORAGE")
+	if defaultStorage == "" {
+		defaultStorage = storageFile
+	}
+	storage := flag.String("storage", defaultStorage, "where to keep your chips: \"file\" or \"memory\" (env CASINO_STORAGE)")
+	resetPolicy := utils.DefaultResetPolicy()
+	flag.IntVar(&resetPolicy.Floor, "refill-chips", resetPolicy.Floor, "chips to top your bankroll back up to on each refill")
+	flag.DurationVar(&resetPolicy.Interval, "refill-every", resetPolicy.Interval, "how often your chips are refilled (0 to disable)")
+	blackjackRules := flag.String("blackjack-rules", "", "JSON file with blackjack table rules (default blackjack.json in the data dir, if it exists)")
+	pokerPaytable := flag.String("poker-paytable", "", "JSON file with 3-card poker bonus payouts (default poker.json in the data dir, if it exists)")
+	videoPokerPaytable := flag.String("videopoker-paytable", "", "JSON file with the video poker paytable (default videopoker.json in the data dir, if it exists)")
+	crapsMaxOdds := flag.Int("craps-max-odds", craps.DefaultMaxOdds, "how many times the flat bet can be taken or laid in craps odds")
+	slotsMachine := flag.String("slots-machine", "", "JSON file describing the slot machine (default slots.json in the data dir, if it exists)")
+	slotsRTP := flag.Bool("slots-rtp", false, "print what the slot machine pays back and exit")
+	flag.Parse()
+
+	if *slotsRTP {
+		os.Exit(printSlotsRTP(*slotsMachine))
+	}
+
 	// out is the channel to write _to_ the user
 	out := make(chan string, 32)
 
 	// inPipe is the pipe from the main process to the game process
-	inPipe := make(chan string) // we'll close this on quit to stop the game
+	inPipe := make(chan string)
 	console := utils.NewConsole(out)
 	defer console.Close()
 
@@ -29,58 +69,337 @@ func main() {
 		runDone <- console.Run(ctx)
 	}()
 
-	saveManager := utils.NewInMemorySaveDataManager()
+	utils.Clear(out)
+	utils.PrintBanner("THE CASINO", out)
+
+	saveManager := utils.NewResettingSaveDataManager(newSaveManager(*storage, out), resetPolicy)
 	dealer := utils.NewDealer()
-	b := blackjack.NewBlackjack(dealer, saveManager, inPipe, out, cancel)
-	p := poker.NewPoker(dealer, saveManager, inPipe, out, cancel)
+	b := blackjack.NewBlackjack(dealer, saveManager, inPipe, out, blackjackOptions(*blackjackRules, out)...)
+	p := poker.NewPoker(dealer, saveManager, inPipe, out, pokerOptions(*pokerPaytable, out)...)
+	h := holdem.NewHoldem(utils.NewDealer(), saveManager, inPipe, out)
+	v := videopoker.NewVideoPoker(utils.NewDealer(), saveManager, inPipe, out, videoPokerOptions(*videoPokerPaytable, out)...)
+	shoe := utils.NewShoe(entities.ShuffleOpts{NumDecks: baccarat.Decks, Penetration: baccarat.Penetration})
+	bac := baccarat.NewBaccarat(shoe, saveManager, inPipe, out)
+	r := roulette.NewRoulette(saveManager, inPipe, out)
+	c := craps.NewCraps(saveManager, inPipe, out, crapsOptions(*crapsMaxOdds, out)...)
+	sl := slots.NewSlots(saveManager, inPipe, out, slotsOptions(*slotsMachine, out)...)
+	k := keno.NewKeno(saveManager, inPipe, out)
 	gameMap := map[int]games.Game{
 		1: b,
 		2: p,
+		3: h,
+		4: v,
+		5: bac,
+		6: r,
+		7: c,
+		8: sl,
+		9: k,
 	}
 
-	utils.Clear(out)
-	bannerTop, bannerMiddle, bannerBottom := utils.Banner("THE CASINO")
-	out <- bannerTop
-	out <- bannerMiddle
-	out <- bannerBottom
+	// The lobby: pick a game, play it until the player leaves the table, then
+	// come back to the menu. Runs until "quit" or the console closes.
+	lobbyDone := make(chan struct{})
+	go func() {
+		defer close(lobbyDone)
+		defer close(inPipe)
+		for {
+			game, ok := chooseGame(console, out, gameMap, saveManager, resetPolicy)
+			if !ok {
+				return
+			}
+
+			gameCtx, stopGame := context.WithCancel(ctx)
+			done := make(chan struct{})
+			var result games.Result
+			go func() {
+				defer close(done)
+				result, _ = game.Play(gameCtx)
+			}()
+
+			stillPlaying := forwardToGame(console, inPipe, done)
+
+			// Wait for the game so a hand in progress is settled before we leave
+			stopGame()
+			<-done
+			if !stillPlaying {
+				console.Close() // cancels Console.Run; closes console.Out
+				return
+			}
+
+			utils.Clear(out)
+			utils.PrintBanner("THE CASINO", out)
+			if result.Rounds > 0 {
+				out <- describeResult(game.Name(), result)
+			}
+		}
+	}()
+
+	// Block until Console.Run returns (e.g., after Close or EOF).
+	err := <-runDone
+
+	// If stdin closed mid-game, the game may still be settling the hand. Nobody
+	// is showing its output anymore, so throw it away until the lobby is done.
+	cancel()
+	for draining := true; draining; {
+		select {
+		case <-out:
+		case <-lobbyDone:
+			draining = false
+		}
+	}
+
+	if err != nil {
+		fmt.Println(utils.Dim("Thanks for playing!"))
+	}
+}
+
+// describeResult sums up how the player did at a game
+func describeResult(name string, result games.Result) string {
+	rounds := fmt.Sprintf("%d rounds", result.Rounds)
+	if result.Rounds == 1 {
+		rounds = "1 round"
+	}
+
+	switch {
+	case result.NetChips > 0:
+		return utils.Green("You left %s up %d chips after %s", name, result.NetChips, rounds)
+	case result.NetChips < 0:
+		return utils.Red("You left %s down %d chips after %s", name, -result.NetChips, rounds)
+	default:
+		return utils.Dim("You left %s even after %s", name, rounds)
+	}
+}
+
+// chooseGame shows the menu and waits for the player to pick a game. Returns
+// false if the player quit or the console closed.
+func chooseGame(
+	console *utils.Console,
+	out chan string,
+	gameMap map[int]games.Game,
+	saveManager utils.SaveDataManager,
+	resetPolicy utils.ResetPolicy,
+) (games.Game, bool) {
+	save := saveManager.Read()
+	out <- fmt.Sprintf("You have %d chips", save.RemainingChips)
+	if resetPolicy.Interval > 0 {
+		untilReset := time.Until(utils.NextResetAt(save, resetPolicy))
+		out <- utils.Dim("Chips refill to %d in %s", resetPolicy.Floor, utils.HumanDuration(untilReset))
+	}
 	out <- utils.Dim("Type 'quit' at any time to leave")
 	out <- "Select a number from the menu below to play:"
-	for id, g := range gameMap {
-		out <- fmt.Sprintf("%d. %s", id, g.Name())
+	for _, id := range slices.Sorted(maps.Keys(gameMap)) {
+		out <- fmt.Sprintf("%d. %s", id, gameMap[id].Name())
 	}
 
-	var choice int
 	for input := range console.Out {
+		if isQuit(input) {
+			console.Close() // cancels Console.Run; closes console.Out
+			return nil, false
+		}
+
 		i64, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
 		if err != nil {
 			out <- utils.Red(fmt.Sprintf("%q is not a valid number", input))
 			continue
 		}
-		i := int(i64)
-		if _, ok := gameMap[i]; ok {
-			choice = i
-			break
+		if game, ok := gameMap[int(i64)]; ok {
+			return game, true
 		}
 		out <- utils.Yellow(fmt.Sprintf("Unknown option: %s", input))
 	}
 
-	game := gameMap[choice]
-	go game.Play()
+	return nil, false
+}
 
-	// Forward subsequent console lines to the game until "quit".
-	go func() {
-		defer close(inPipe) // let blackjack.Play exit its range
-		for line := range console.Out {
-			if strings.EqualFold(strings.TrimSpace(line), "quit") {
-				console.Close() // cancels Console.Run; closes console.Out
-				return
+// forwardToGame passes console lines on to the running game until it finishes.
+// Returns false if the player quit or the console closed.
+func forwardToGame(console *utils.Console, inPipe chan<- string, done <-chan struct{}) bool {
+	for {
+		select {
+		case <-done:
+			return true
+		case line, ok := <-console.Out:
+			if !ok || isQuit(line) {
+				return false
+			}
+
+			select {
+			case inPipe <- line:
+			case <-done:
+				// The game finished without reading the line
+				return true
 			}
-			inPipe <- line
 		}
-	}()
+	}
+}
 
-	// Block until Console.Run returns (e.g., after Close or EOF).
-	if err := <-runDone; err != nil {
-		fmt.Println(utils.Dim("Thanks for playing!"))
+func isQuit(line string) bool {
+	return strings.EqualFold(strings.TrimSpace(line), "quit")
+}
+
+// newSaveManager picks the save data storage. If the save file can't be used we
+// warn the player and fall back to something that still lets them play.
+func newSaveManager(storage string, out chan string) utils.SaveDataManager {
+	switch strings.ToLower(storage) {
+	case storageMemory:
+		return utils.NewInMemorySaveDataManager()
+	case storageFile:
+	default:
+		out <- utils.Yellow("Unknown storage %q, saving to a file instead", storage)
+	}
+
+	path, err := utils.SaveFilePath()
+	if err != nil {
+		out <- utils.Yellow("Could not find a place to save your chips (%s), they will not be saved", err.Error())
+		return utils.NewInMemorySaveDataManager()
 	}
+
+	saveManager, err := utils.NewFileSaveDataManager(path, out)
+	if errors.Is(err, utils.ErrCorruptSaveData) {
+		out <- utils.Yellow("Your save file was corrupt and has been reset (%s)", err.Error())
+		return saveManager
+	}
+	if err != nil {
+		out <- utils.Yellow("Could not load your save file (%s), your chips will not be saved", err.Error())
+		return utils.NewInMemorySaveDataManager()
+	}
+
+	return saveManager
+}
+
+// blackjackOptions loads the blackjack table rules from path, or from the data dir
+// when no path was given. Without a rules file the player picks a table.
+func blackjackOptions(path string, out chan string) []blackjack.Option {
+	if path == "" {
+		dir, err := utils.GetDataDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "blackjack.json")
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+
+	rules, err := blackjack.LoadRules(path)
+	if err != nil {
+		out <- utils.Yellow("Could not load blackjack rules (%s), you can pick a table instead", err.Error())
+		return nil
+	}
+
+	return []blackjack.Option{blackjack.WithRules(rules)}
+}
+
+// pokerOptions loads the 3-card poker paytable from path, or from the data dir
+// when no path was given. Without a paytable file the default payouts are used.
+func pokerOptions(path string, out chan string) []poker.Option {
+	if path == "" {
+		dir, err := utils.GetDataDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "poker.json")
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+
+	paytable, err := poker.LoadPaytable(path)
+	if err != nil {
+		out <- utils.Yellow("Could not load the 3-card poker paytable (%s), using the default payouts instead", err.Error())
+		return nil
+	}
+
+	return []poker.Option{poker.WithPaytable(paytable)}
+}
+
+// videoPokerOptions loads the video poker paytable from path, or from the data
+// dir when no path was given. Without a paytable file the machine pays 9/6.
+func videoPokerOptions(path string, out chan string) []videopoker.Option {
+	if path == "" {
+		dir, err := utils.GetDataDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "videopoker.json")
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+
+	paytable, err := videopoker.LoadPaytable(path)
+	if err != nil {
+		out <- utils.Yellow("Could not load the video poker paytable (%s), playing 9/6 Jacks or Better instead", err.Error())
+		return nil
+	}
+
+	return []videopoker.Option{videopoker.WithPaytable(paytable)}
+}
+
+// crapsOptions sets the craps table's odds limit. A limit below 1 falls back to
+// the default.
+func crapsOptions(maxOdds int, out chan string) []craps.Option {
+	if maxOdds < 1 {
+		out <- utils.Yellow("Craps odds must be at least 1x the flat bet, got %d, using %dx instead", maxOdds, craps.DefaultMaxOdds)
+		return nil
+	}
+
+	return []craps.Option{craps.WithMaxOdds(maxOdds)}
+}
+
+// loadSlotsMachine loads the slot machine from path, or from the data dir when
+// no path was given. Returns false if there's no machine file to load.
+func loadSlotsMachine(path string) (slots.Machine, bool, error) {
+	if path == "" {
+		dir, err := utils.GetDataDir()
+		if err != nil {
+			return slots.Machine{}, false, nil
+		}
+		path = filepath.Join(dir, "slots.json")
+		if _, err := os.Stat(path); err != nil {
+			return slots.Machine{}, false, nil
+		}
+	}
+
+	machine, err := slots.LoadMachine(path)
+	return machine, true, err
+}
+
+// slotsOptions loads the slot machine to play. Without a machine file the
+// default machine is played.
+func slotsOptions(path string, out chan string) []slots.Option {
+	machine, ok, err := loadSlotsMachine(path)
+	if err != nil {
+		out <- utils.Yellow("Could not load the slot machine (%s), playing the default machine instead", err.Error())
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	return []slots.Option{slots.WithMachine(machine)}
+}
+
+// printSlotsRTP works out what the slot machine pays back and prints it.
+// Returns the exit code.
+func printSlotsRTP(path string) int {
+	machine, ok, err := loadSlotsMachine(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load the slot machine: %s\n", err.Error())
+		return 1
+	}
+	if !ok {
+		machine = slots.DefaultMachine()
+	}
+
+	report, err := slots.CalculateRTP(machine)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not calculate the RTP: %s\n", err.Error())
+		return 1
+	}
+	for _, line := range report.Describe() {
+		fmt.Println(line)
+	}
+
+	return 0
 }
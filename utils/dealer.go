@@ -1,40 +1,113 @@
 package utils
 
 import (
+	"errors"
+	"slices"
+
 	"casino/entities"
 	"casino/mappers"
 )
 
+// ErrDeckEmpty is returned when there are no cards left to draw
+var ErrDeckEmpty = errors.New("the dealer has run out of cards")
+
+// DefaultPenetration is how much of the shoe is dealt before the cut card comes
+// out when no penetration is given
+const DefaultPenetration = 0.75
+
 type Dealer struct {
 	CurrentDeck entities.Deck
+
+	opts entities.ShuffleOpts
+	// cutCard is how many cards are left in the draw pile when the cut card comes out
+	cutCard int
 }
 
+// NewDealer creates a dealer with a single shuffled deck
 func NewDealer() Dealer {
-	deck := GenerateStandardDeck()
+	return NewShoe(entities.ShuffleOpts{NumDecks: 1})
+}
+
+// NewShoe creates a dealer with opts.NumDecks standard decks shuffled together
+// into one shoe, with the cut card placed at opts.Penetration
+func NewShoe(opts entities.ShuffleOpts) Dealer {
+	opts.NumDecks = max(1, opts.NumDecks)
+	if opts.Penetration <= 0 || opts.Penetration > 1 {
+		opts.Penetration = DefaultPenetration
+	}
+
+	var deck entities.Deck
+	for range opts.NumDecks {
+		deck.DrawPile = append(deck.DrawPile, GenerateStandardDeck().DrawPile...)
+	}
 	dealer := Dealer{
 		CurrentDeck: deck,
+		opts:        opts,
 	}
 	dealer.Shuffle()
 
 	return dealer
 }
 
+// Shuffle gathers the discard pile back into the shoe, shuffles everything and
+// places the cut card. The cards go in a new slice, so a copy of the dealer
+// never sees its shoe rearranged.
 func (d *Dealer) Shuffle() {
-	allCards := append(d.CurrentDeck.DrawPile, d.CurrentDeck.DiscardPile...)
+	allCards := slices.Concat(d.CurrentDeck.DrawPile, d.CurrentDeck.DiscardPile)
 	Shuffle(allCards)
 	d.CurrentDeck.DrawPile = allCards
+	d.CurrentDeck.DiscardPile = nil
+
+	dealt := int(float64(len(allCards)) * d.opts.Penetration)
+	d.cutCard = len(allCards) - dealt
+}
+
+// NeedsShuffle reports whether the cut card has come out. Games check this
+// between rounds so a round is never interrupted by a shuffle.
+func (d *Dealer) NeedsShuffle() bool {
+	return len(d.CurrentDeck.DrawPile) <= d.cutCard
+}
+
+// Remaining is how many cards are left to deal before the cut card comes out
+func (d *Dealer) Remaining() int {
+	return max(0, len(d.CurrentDeck.DrawPile)-d.cutCard)
 }
 
-func (d *Dealer) Draw() entities.Card {
+// NumDecks is how many decks make up the shoe
+func (d *Dealer) NumDecks() int {
+	return d.opts.NumDecks
+}
+
+// Draw deals the next card face up. If the draw pile is empty the discard pile
+// is shuffled back in when the dealer was created with ReshuffleDiscards,
+// otherwise ErrDeckEmpty is returned.
+func (d *Dealer) Draw() (entities.Card, error) {
+	if len(d.CurrentDeck.DrawPile) == 0 && d.opts.ReshuffleDiscards {
+		d.Shuffle()
+	}
 	if len(d.CurrentDeck.DrawPile) == 0 {
-		return entities.Card{}
+		return entities.Card{}, ErrDeckEmpty
 	}
 
 	card := d.CurrentDeck.DrawPile[0]
 	card.Hidden = false
 	d.CurrentDeck.DrawPile = d.CurrentDeck.DrawPile[1:]
 
-	return card
+	return card, nil
+}
+
+// DrawCards deals opts.Count cards, face up
+func (d *Dealer) DrawCards(opts entities.DrawOpts) ([]entities.Card, error) {
+	cards := make([]entities.Card, 0, opts.Count)
+	for range opts.Count {
+		card, err := d.Draw()
+		if err != nil {
+			return cards, err
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, nil
 }
 
 func (d *Dealer) Discard(cards ...entities.Card) {
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"casino/entities"
+)
+
+func TestShuffleLeavesCopiesAlone(t *testing.T) {
+	dealer := NewDealer()
+	hand, err := dealer.DrawCards(entities.DrawOpts{Count: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dealer.Discard(hand...)
+	drawPile := slices.Clone(dealer.CurrentDeck.DrawPile)
+
+	// Another game holding a copy of the dealer shuffles between its hands
+	other := dealer
+	other.Shuffle()
+
+	if !slices.Equal(dealer.CurrentDeck.DrawPile, drawPile) {
+		t.Fatal("shuffling a copy of the dealer rearranged the original's draw pile")
+	}
+	for _, card := range dealer.CurrentDeck.DrawPile {
+		if slices.ContainsFunc(dealer.CurrentDeck.DiscardPile, func(c entities.Card) bool { return c.Code == card.Code }) {
+			t.Fatalf("%s is in both the draw pile and the discard pile", card.Code)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "math/rand"
+
+// Dice are a set of identical dice, e.g. two six sided dice for craps
+type Dice struct {
+	count int
+	sides int
+}
+
+func NewDice(count, sides int) Dice {
+	return Dice{count: max(1, count), sides: max(2, sides)}
+}
+
+// Roll rolls every die and returns the faces, each from 1 to the number of sides
+func (d Dice) Roll() []int {
+	faces := make([]int, d.count)
+	for i := range faces {
+		faces[i] = rand.Intn(d.sides) + 1
+	}
+
+	return faces
+}
@@ -1,17 +1,54 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func GetBet(in chan string, out chan string, message string, minChips, maxChips int) int {
-	var wager int
+// ErrInputClosed is returned when the player's input channel is closed while
+// waiting for them to type something
+var ErrInputClosed = errors.New("input closed")
 
+// ReadLine waits for the next line from in. It returns ctx.Err() if ctx is
+// canceled first, or ErrInputClosed if in is closed.
+func ReadLine(ctx context.Context, in chan string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case line, ok := <-in:
+		if !ok {
+			return "", ErrInputClosed
+		}
+		return line, nil
+	}
+}
+
+// Sleep waits for d, or until ctx is canceled, so a pause in the game never
+// holds up leaving it
+func Sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+func GetBet(ctx context.Context, in chan string, out chan string, message string, minChips, maxChips int) (int, error) {
 	out <- message
-	for line := range in {
+	for {
+		line, err := ReadLine(ctx, in)
+		if err != nil {
+			return 0, err
+		}
+
 		// Attempt to convert to int
 		i64, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
 		if err != nil {
@@ -20,31 +57,32 @@ func GetBet(in chan string, out chan string, message string, minChips, maxChips
 			continue
 		}
 
-		wager = int(i64)
+		wager := int(i64)
 		if wager < minChips {
-			out <- Yellow(Bold("Wager must be at least 1"))
+			out <- Yellow(Bold(fmt.Sprintf("Wager must be at least %d", minChips)))
 		} else if wager > maxChips {
 			out <- Yellow(Bold(fmt.Sprintf("You cannot wager %d, you only have %d", wager, maxChips)))
 		} else {
-			break
+			return wager, nil
 		}
 
 		out <- message
 	}
-
-	return wager
 }
 
 // GetInput takes in a list of commands and waits for user to choose one. It returns
 // the command that was chosen
-func GetInput(in, out chan string, commands []string, message string) string {
+func GetInput(ctx context.Context, in, out chan string, commands []string, message string) (string, error) {
 	out <- message
-	for line := range in {
+	for {
+		line, err := ReadLine(ctx, in)
+		if err != nil {
+			return "", err
+		}
+
 		lowerLine := strings.TrimSpace(strings.ToLower(line))
 		if slices.Contains(commands, lowerLine) {
-			return lowerLine
+			return lowerLine, nil
 		}
 	}
-
-	return ""
 }
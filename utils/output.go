@@ -36,6 +36,23 @@ func Clear(out chan string) {
 
 func Divider() string { return strings.Repeat("─", 52) }
 
+// Redraw overwrites the last n lines sent to the console with lines, so part of
+// the screen can be animated in place. Send it as a single message.
+func Redraw(n int, lines []string) string {
+	var b strings.Builder
+	if n > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", n)
+	}
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString("\r\x1b[K" + line)
+	}
+
+	return b.String()
+}
+
 func Banner(title string) (top, middle, bottom string) {
 	titleDecor := "═" + title + "═"
 	left := max(0, (26 - len(StripANSI(titleDecor))))
@@ -117,8 +134,38 @@ func RenderCard(c entities.Card) []string {
 	}
 }
 
+// JoinColumns lays blocks of lines out side by side, padding each block to its
+// widest line so the columns stay aligned
+func JoinColumns(gap string, columns ...[]string) []string {
+	height := 0
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		height = max(height, len(column))
+		for _, line := range column {
+			widths[i] = max(widths[i], VisibleWidth(line))
+		}
+	}
+
+	out := make([]string, height)
+	for r := range height {
+		var parts []string
+		for i, column := range columns {
+			line := ""
+			if r < len(column) {
+				line = column[r]
+			}
+			parts = append(parts, line+strings.Repeat(" ", widths[i]-VisibleWidth(line)))
+		}
+		out[r] = strings.TrimRight(strings.Join(parts, gap), " ")
+	}
+	return out
+}
+
 // Helpers
 
+// VisibleWidth is the number of characters s takes up on screen, ignoring colors
+func VisibleWidth(s string) int { return RuneCount(StripANSI(s)) }
+
 func StripANSI(s string) string {
 	// Remove sequences like \x1b[...m
 	var out strings.Builder
@@ -6,6 +6,9 @@ import (
 	"casino/entities"
 )
 
+// DefaultChips is how many chips a brand new player starts with
+const DefaultChips = 1000
+
 type SaveDataManager interface {
 	Save(data entities.SaveData)
 	Read() entities.SaveData
@@ -29,6 +32,78 @@ func (b *inMemorySaveDataManager) Read() entities.SaveData {
 	return b.saveData
 }
 
+// ResetPolicy describes how often a player's chips are refilled and to how many
+type ResetPolicy struct {
+	// Floor is the number of chips the player is topped back up to
+	Floor int
+	// Interval is how long to wait between refills. Refills happen on multiples of
+	// the interval after the last reset, so a 24 hour interval lands on UTC midnight.
+	Interval time.Duration
+}
+
+func DefaultResetPolicy() ResetPolicy {
+	return ResetPolicy{
+		Floor:    DefaultChips,
+		Interval: 24 * time.Hour,
+	}
+}
+
+// NewResettingSaveDataManager wraps a SaveDataManager so that reading the save
+// applies the reset policy, refilling the player's chips when a reset is due
+func NewResettingSaveDataManager(inner SaveDataManager, policy ResetPolicy) SaveDataManager {
+	return &resettingSaveDataManager{
+		inner:  inner,
+		policy: policy,
+	}
+}
+
+type resettingSaveDataManager struct {
+	inner  SaveDataManager
+	policy ResetPolicy
+}
+
+func (r *resettingSaveDataManager) Save(data entities.SaveData) {
+	r.inner.Save(data)
+}
+
+func (r *resettingSaveDataManager) Read() entities.SaveData {
+	data, reset := ApplyReset(r.inner.Read(), r.policy, time.Now())
+	if reset {
+		r.inner.Save(data)
+	}
+
+	return data
+}
+
+// ApplyReset refills the chips in data if a reset is due at now. It returns the
+// updated save data and whether a reset happened.
+func ApplyReset(data entities.SaveData, policy ResetPolicy, now time.Time) (entities.SaveData, bool) {
+	if policy.Interval <= 0 {
+		return data, false
+	}
+
+	if data.LastResetAt.IsZero() {
+		data.LastResetAt = getPreviousMidnight()
+	}
+	if now.Before(data.LastResetAt.Add(policy.Interval)) {
+		return data, false
+	}
+
+	// Move forward by whole intervals so resets stay on the same schedule
+	elapsed := now.Sub(data.LastResetAt)
+	data.LastResetAt = data.LastResetAt.Add(elapsed.Truncate(policy.Interval))
+	if data.RemainingChips < policy.Floor {
+		data.RemainingChips = policy.Floor
+	}
+
+	return data, true
+}
+
+// NextResetAt returns when the chips in data will next be refilled
+func NextResetAt(data entities.SaveData, policy ResetPolicy) time.Time {
+	return data.LastResetAt.Add(policy.Interval)
+}
+
 func getPreviousMidnight() time.Time {
 	year, month, day := time.Now().UTC().Date()
 	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
@@ -36,7 +111,7 @@ func getPreviousMidnight() time.Time {
 
 func defaultSaveData() entities.SaveData {
 	return entities.SaveData{
-		RemainingChips: 1000,
+		RemainingChips: DefaultChips,
 		LastResetAt:    getPreviousMidnight(),
 	}
 }
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"casino/entities"
+)
+
+const saveFileName = "save.json"
+
+// ErrCorruptSaveData is returned by NewFileSaveDataManager when the existing save
+// file could not be understood. The returned manager is still usable and starts
+// over from the default save data.
+var ErrCorruptSaveData = errors.New("save data is corrupt")
+
+// SaveFilePath returns the path of the save file inside the user's data directory
+func SaveFilePath() (string, error) {
+	dir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, saveFileName), nil
+}
+
+// NewFileSaveDataManager creates a SaveDataManager backed by a JSON file at path.
+// The directory is created if needed. If the file does not exist yet the default
+// save data is used. If it exists but can't be parsed, it is moved aside to
+// <path>.corrupt, the default save data is used, and ErrCorruptSaveData is returned
+// alongside the manager. Write failures while saving are reported on out.
+func NewFileSaveDataManager(path string, out chan string) (SaveDataManager, error) {
+	if err := EnsureDirs(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	m := &fileSaveDataManager{
+		path: path,
+		out:  out,
+	}
+
+	data, err := loadSaveFile(path)
+	switch {
+	case err == nil:
+		m.saveData = data
+		return m, nil
+	case errors.Is(err, os.ErrNotExist):
+		m.saveData = defaultSaveData()
+		return m, m.write(m.saveData)
+	case errors.Is(err, ErrCorruptSaveData):
+		m.saveData = defaultSaveData()
+		// Keep the broken file around in case someone wants to look at it
+		_ = os.Rename(path, path+".corrupt")
+		if writeErr := m.write(m.saveData); writeErr != nil {
+			return nil, writeErr
+		}
+		return m, err
+	default:
+		return nil, err
+	}
+}
+
+type fileSaveDataManager struct {
+	path string
+	out  chan string
+
+	mu       sync.Mutex
+	saveData entities.SaveData
+}
+
+func (f *fileSaveDataManager) Save(data entities.SaveData) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.saveData = data
+	if err := f.write(data); err != nil && f.out != nil {
+		f.out <- Red("Could not save your chips: %s", err.Error())
+	}
+}
+
+func (f *fileSaveDataManager) Read() entities.SaveData {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.saveData
+}
+
+// write atomically replaces the save file by writing to a temporary file in the
+// same directory and renaming it over the original
+func (f *fileSaveDataManager) write(data entities.SaveData) error {
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), saveFileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once the rename succeeds
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, f.path)
+}
+
+func loadSaveFile(path string) (entities.SaveData, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return entities.SaveData{}, err
+	}
+
+	var data entities.SaveData
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return entities.SaveData{}, fmt.Errorf("%w: %s", ErrCorruptSaveData, err.Error())
+	}
+	if data.RemainingChips < 0 || data.LastResetAt.IsZero() {
+		return entities.SaveData{}, fmt.Errorf("%w: invalid values", ErrCorruptSaveData)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"casino/entities"
+)
+
+func TestApplyReset(t *testing.T) {
+	lastReset := time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC)
+	daily := ResetPolicy{Floor: 100, Interval: 24 * time.Hour}
+
+	tests := []struct {
+		name   string
+		policy ResetPolicy
+		chips  int
+		now    time.Time
+
+		reset       bool
+		wantChips   int
+		wantResetAt time.Time
+	}{
+		{"just before the boundary", daily, 20, lastReset.Add(24*time.Hour - time.Nanosecond), false, 20, lastReset},
+		{"exactly on the boundary", daily, 20, lastReset.Add(24 * time.Hour), true, 100, lastReset.Add(24 * time.Hour)},
+		{"just after the boundary", daily, 20, lastReset.Add(24*time.Hour + time.Second), true, 100, lastReset.Add(24 * time.Hour)},
+		{"several intervals missed", daily, 20, lastReset.Add(60 * time.Hour), true, 100, lastReset.Add(48 * time.Hour)},
+		{"chips above the floor are kept", daily, 500, lastReset.Add(24 * time.Hour), true, 500, lastReset.Add(24 * time.Hour)},
+		{"short interval", ResetPolicy{Floor: 50, Interval: 7 * time.Hour}, 0, lastReset.Add(14 * time.Hour), true, 50, lastReset.Add(14 * time.Hour)},
+		{"refills turned off", ResetPolicy{Floor: 100}, 20, lastReset.Add(240 * time.Hour), false, 20, lastReset},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := entities.SaveData{RemainingChips: test.chips, LastResetAt: lastReset}
+
+			got, reset := ApplyReset(data, test.policy, test.now)
+			if reset != test.reset {
+				t.Errorf("reset = %t, want %t", reset, test.reset)
+			}
+			if got.RemainingChips != test.wantChips {
+				t.Errorf("chips = %d, want %d", got.RemainingChips, test.wantChips)
+			}
+			if !got.LastResetAt.Equal(test.wantResetAt) {
+				t.Errorf("last reset at %s, want %s", got.LastResetAt, test.wantResetAt)
+			}
+		})
+	}
+}
+
+func TestApplyResetKeepsSchedule(t *testing.T) {
+	policy := ResetPolicy{Floor: 100, Interval: 24 * time.Hour}
+	data := entities.SaveData{LastResetAt: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC)}
+
+	// A refill late in the day doesn't push the next one back
+	data, _ = ApplyReset(data, policy, data.LastResetAt.Add(47*time.Hour))
+	want := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	if next := NextResetAt(data, policy); !next.Equal(want) {
+		t.Errorf("next reset at %s, want %s", next, want)
+	}
+}
@@ -29,6 +29,19 @@ func Shuffle[T any](arr []T) {
 	}
 }
 
+// Sample picks n different items from arr at random, in the order they were
+// picked, like balls drawn from a hopper. arr is left as it was.
+func Sample[T any](arr []T, n int) []T {
+	pool := slices.Clone(arr)
+	n = min(n, len(pool))
+	for i := range n {
+		j := i + rand.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+
+	return pool[:n]
+}
+
 func Pop[T any](arr []T) T {
 	var ret T
 	if len(arr) == 0 {
@@ -46,7 +59,7 @@ func Any[T any](arr []T, f func(T) bool) bool {
 
 func All[T any](arr []T, f func(T) bool) bool {
 	fWrapper := func(i T) bool { return !f(i) }
-	return Any(arr, fWrapper)
+	return !Any(arr, fWrapper)
 }
 
 func Dedupe[T comparable](arr []T) []T {
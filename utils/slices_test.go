@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	original := slices.Clone(arr)
+
+	for _, n := range []int{0, 1, 5, 10, 15} {
+		sample := Sample(arr, n)
+		if want := min(n, len(arr)); len(sample) != want {
+			t.Errorf("Sample(%d) returned %d items, want %d", n, len(sample), want)
+		}
+		for _, item := range sample {
+			if !slices.Contains(original, item) {
+				t.Errorf("Sample(%d) returned %d, which isn't in the input", n, item)
+			}
+		}
+		sorted := slices.Sorted(slices.Values(sample))
+		if len(slices.Compact(sorted)) != len(sample) {
+			t.Errorf("Sample(%d) = %v, want distinct items", n, sample)
+		}
+		if !slices.Equal(arr, original) {
+			t.Fatalf("Sample(%d) changed its input to %v", n, arr)
+		}
+	}
+}
+
+func TestSampleIsRandom(t *testing.T) {
+	// Every item should come first sometimes
+	arr := []int{1, 2, 3, 4}
+	first := map[int]bool{}
+	for range 1000 {
+		first[Sample(arr, 2)[0]] = true
+	}
+	if len(first) != len(arr) {
+		t.Errorf("only %v were ever picked first", first)
+	}
+}
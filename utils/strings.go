@@ -1,6 +1,11 @@
 package utils
 
-import "unicode/utf8"
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 func PadRight(s string, n int) string {
 	for RuneCount(s) < n {
@@ -17,6 +22,12 @@ func PadLeft(s string, n int) string {
 	return CutRunes(s, n)
 }
 
+// PadCenter pads s with spaces on both sides to n characters
+func PadCenter(s string, n int) string {
+	left := (n - RuneCount(s)) / 2
+	return PadRight(strings.Repeat(" ", max(0, left))+s, n)
+}
+
 func RuneCount(s string) int { return utf8.RuneCountInString(s) }
 
 func CutRunes(s string, n int) string {
@@ -26,3 +37,20 @@ func CutRunes(s string, n int) string {
 	}
 	return string(runes[:n])
 }
+
+// HumanDuration formats d as hours and minutes, e.g. "5h 3m". Anything under a
+// minute is shown as "less than a minute".
+func HumanDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "less than a minute"
+	}
+
+	d = d.Truncate(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}